@@ -1,3 +1,3 @@
 module github.com/dgravesa/go-parallel
 
-go 1.14
+go 1.21
@@ -14,6 +14,10 @@ func newAtomicCounterStrategy() Strategy {
 	}
 }
 
+func (s *atomicCounterStrategy) newLoop() Strategy {
+	return newAtomicCounterStrategy()
+}
+
 func (s *atomicCounterStrategy) IndexGenerator(_, _, _ int) IndexGenerator {
 	return &atomicIndexGenerator{
 		counterAddr: &s.counter,
@@ -0,0 +1,341 @@
+package parallel
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// autoSampleFraction is the fraction of each goroutine's block whose iterations are timed
+	autoSampleFraction = 0.03
+	// autoMinSamples and autoMaxSamples bound the number of timed iterations per goroutine
+	autoMinSamples = 8
+	autoMaxSamples = 64
+	// autoChunksPerGoroutine is the number of chunks each block is claimed in
+	autoChunksPerGoroutine = 16
+
+	// iterations faster than autoMinDynamicTime stay on preassigned blocks, as the cost of
+	// dynamic distribution is comparable to the iterations themselves
+	autoMinDynamicTime = 5 * time.Microsecond
+	// autoMaxVariation is the largest coefficient of variation of iteration times that stays on
+	// preassigned blocks
+	autoMaxVariation = 1.0
+	// autoMaxImbalance is the largest ratio between the slowest and fastest goroutine's mean
+	// iteration time that stays on preassigned blocks
+	autoMaxImbalance = 1.5
+)
+
+// AutoDecision describes the choice made by an automatic strategy during a loop.
+type AutoDecision struct {
+	// Strategy is StrategyPreassignIndices if each goroutine kept its contiguous block of
+	// indices, or StrategyFetchNextIndex if the remaining work was distributed dynamically.
+	Strategy StrategyType
+
+	// NumSamples is the number of iterations that were timed before the decision was made.
+	NumSamples int
+	// MeanIterationTime is the mean time of the timed iterations.
+	MeanIterationTime time.Duration
+	// Variation is the coefficient of variation of the timed iterations, which is the standard
+	// deviation of the iteration times divided by their mean.
+	Variation float64
+	// Imbalance is the ratio between the largest and smallest mean iteration time among
+	// goroutines that reported samples.
+	Imbalance float64
+	// EarlyFinish is true if a goroutine finished its block before all goroutines had finished
+	// sampling, which forces the remaining work to be distributed dynamically.
+	EarlyFinish bool
+}
+
+// AutoStrategy is a strategy that chooses between preassigned and dynamic distribution of work
+// indices at runtime. The automatic strategy is also available as StrategyAuto through
+// WithStrategy(); AutoStrategy is only needed to inspect its decisions.
+//
+// Each goroutine starts on a contiguous block of indices, as in StrategyPreassignIndices, and
+// times the iterations in the first few percent of its block. Once every goroutine has reported
+// its timings, the remaining work either stays on the preassigned blocks, or is switched to
+// dynamic distribution where goroutines that finish their own block claim chunks of indices from
+// the blocks of other goroutines. The switch happens if the iteration times vary widely, if some
+// goroutines are much slower than others, or if a goroutine finishes its block before all
+// goroutines have finished sampling. Loops whose iterations take less than a few microseconds
+// always stay on preassigned blocks.
+//
+// Iterations are timed between successive calls to the index generator, so the timings are only
+// meaningful when the strategy is used with For() or ForWithContext().
+type AutoStrategy struct {
+	mu           sync.Mutex
+	lastDecision AutoDecision
+	decided      bool
+
+	// loop shared by index generators created outside of an executor loop
+	directLoop   *autoLoop
+	directNumGR  int
+	directN      int
+	directIssued []bool
+}
+
+// NewAutoStrategy returns a new automatic strategy, which is used with WithCustomStrategy().
+// A new decision is made for every loop, and the most recent one is returned by LastDecision().
+func NewAutoStrategy() *AutoStrategy {
+	return new(AutoStrategy)
+}
+
+// LastDecision returns the decision made during the most recent loop executed with this strategy.
+// The second return value is false if no decision has been made yet.
+// If loops run concurrently with the same strategy, the decision of any one of them is returned.
+func (s *AutoStrategy) LastDecision() (AutoDecision, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastDecision, s.decided
+}
+
+func (s *AutoStrategy) recordDecision(decision AutoDecision) {
+	s.mu.Lock()
+	s.lastDecision = decision
+	s.decided = true
+	s.mu.Unlock()
+}
+
+func (s *AutoStrategy) newLoop() Strategy {
+	return &autoLoop{parent: s}
+}
+
+// IndexGenerator is only reached when the strategy is used outside of an executor loop, such as
+// when it is wrapped by another strategy. Generators share the state of a single loop until the
+// loop size changes or a generator is created again for the same goroutine ID, which starts a new
+// loop. Loops using the same strategy outside of an executor must therefore not run concurrently.
+func (s *AutoStrategy) IndexGenerator(numGR, grID, N int) IndexGenerator {
+	s.mu.Lock()
+	if s.directLoop == nil || s.directNumGR != numGR || s.directN != N ||
+		grID < 0 || grID >= numGR || s.directIssued[grID] {
+		s.directLoop = &autoLoop{parent: s}
+		s.directNumGR = numGR
+		s.directN = N
+		s.directIssued = make([]bool, maxInt(numGR, 0))
+	}
+	if grID >= 0 && grID < numGR {
+		s.directIssued[grID] = true
+	}
+	loop := s.directLoop
+	s.mu.Unlock()
+
+	return loop.IndexGenerator(numGR, grID, N)
+}
+
+// autoMode is the distribution mode of an automatic strategy loop.
+type autoMode = int32
+
+const (
+	autoUndecided = autoMode(iota)
+	autoPreassigned
+	autoDynamic
+)
+
+// autoLoop holds the state shared by the index generators of a single loop.
+type autoLoop struct {
+	parent *AutoStrategy
+
+	init      sync.Once
+	blocks    []autoBlock
+	chunkSize int
+	mode      atomic.Int32
+
+	mu          sync.Mutex
+	numReported int
+	all         meanVariance
+	grMeans     []float64
+}
+
+// autoBlock is a goroutine's contiguous block of indices, which is claimed through a cursor so
+// that other goroutines may claim from it after a switch to dynamic distribution.
+type autoBlock struct {
+	next int64
+	stop int64
+}
+
+// claim reserves up to n indices from the block, returning the claimed range [lo, hi).
+func (b *autoBlock) claim(n int) (int, int, bool) {
+	lo := atomic.AddInt64(&b.next, int64(n)) - int64(n)
+	if lo >= b.stop {
+		return 0, 0, false
+	}
+	hi := lo + int64(n)
+	if hi > b.stop {
+		hi = b.stop
+	}
+	return int(lo), int(hi), true
+}
+
+func (l *autoLoop) IndexGenerator(numGR, grID, N int) IndexGenerator {
+	l.init.Do(func() {
+		l.blocks = make([]autoBlock, numGR)
+		for id := range l.blocks {
+			start, stop := grIndexBlock(numGR, id, N)
+			l.blocks[id] = autoBlock{next: int64(start), stop: int64(stop)}
+		}
+		l.chunkSize = maxInt(N/(numGR*autoChunksPerGoroutine), 1)
+		l.grMeans = make([]float64, 0, numGR)
+	})
+
+	start, stop := grIndexBlock(numGR, grID, N)
+	numSamples := int(math.Ceil(float64(stop-start) * autoSampleFraction))
+	numSamples = minInt(maxInt(numSamples, autoMinSamples), autoMaxSamples)
+
+	return &autoIndexGenerator{
+		loop:       l,
+		grID:       grID,
+		doneIndex:  N,
+		numSamples: minInt(numSamples, stop-start),
+		sampling:   true,
+	}
+}
+
+// report adds a goroutine's iteration timings, and makes the decision once all goroutines have
+// reported.
+func (l *autoLoop) report(samples meanVariance) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.numReported++
+	if samples.count > 0 {
+		l.all.merge(samples)
+		l.grMeans = append(l.grMeans, samples.mean)
+	}
+
+	if l.numReported == len(l.blocks) && l.mode.Load() == autoUndecided {
+		l.decide(false)
+	}
+}
+
+// finishEarly switches to dynamic distribution if no decision has been made yet.
+func (l *autoLoop) finishEarly() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.mode.Load() == autoUndecided {
+		l.decide(true)
+	}
+}
+
+// decide chooses the distribution mode from the reported timings. l.mu must be held.
+func (l *autoLoop) decide(earlyFinish bool) {
+	decision := AutoDecision{
+		Strategy:          StrategyPreassignIndices,
+		NumSamples:        l.all.count,
+		MeanIterationTime: time.Duration(l.all.mean),
+		Imbalance:         1,
+		EarlyFinish:       earlyFinish,
+	}
+
+	if l.all.count > 1 && l.all.mean > 0 {
+		decision.Variation = math.Sqrt(l.all.m2/float64(l.all.count-1)) / l.all.mean
+	}
+	if len(l.grMeans) > 0 {
+		minMean, maxMean := l.grMeans[0], l.grMeans[0]
+		for _, mean := range l.grMeans[1:] {
+			minMean = math.Min(minMean, mean)
+			maxMean = math.Max(maxMean, mean)
+		}
+		if minMean > 0 {
+			decision.Imbalance = maxMean / minMean
+		}
+	}
+
+	imbalanced := decision.Variation > autoMaxVariation || decision.Imbalance > autoMaxImbalance
+	if earlyFinish || (decision.MeanIterationTime >= autoMinDynamicTime && imbalanced) {
+		decision.Strategy = StrategyFetchNextIndex
+		l.mode.Store(autoDynamic)
+	} else {
+		l.mode.Store(autoPreassigned)
+	}
+
+	l.parent.recordDecision(decision)
+}
+
+type autoIndexGenerator struct {
+	loop      *autoLoop
+	grID      int
+	doneIndex int
+
+	// range of claimed indices not yet returned
+	lo, hi int
+
+	sampling   bool
+	numSamples int
+	samples    meanVariance
+	lastTime   time.Time
+}
+
+func (g *autoIndexGenerator) Next() int {
+	if g.sampling {
+		g.sample()
+	}
+
+	if g.lo < g.hi {
+		i := g.lo
+		g.lo++
+		return i
+	}
+
+	return g.refill()
+}
+
+// sample times the iteration that ended with this call to Next().
+func (g *autoIndexGenerator) sample() {
+	now := time.Now()
+	if !g.lastTime.IsZero() {
+		g.samples.add(float64(now.Sub(g.lastTime)))
+	}
+	g.lastTime = now
+
+	if g.samples.count >= g.numSamples {
+		g.stopSampling()
+	}
+}
+
+func (g *autoIndexGenerator) stopSampling() {
+	g.sampling = false
+	g.loop.report(g.samples)
+}
+
+// refill claims the next range of indices, returning its first index.
+func (g *autoIndexGenerator) refill() int {
+	l := g.loop
+
+	// claim single indices from the own block while sampling so that every sampled iteration
+	// is timed individually
+	claimSize := l.chunkSize
+	if g.sampling {
+		claimSize = 1
+	}
+	if lo, hi, ok := l.blocks[g.grID].claim(claimSize); ok {
+		return g.take(lo, hi)
+	}
+
+	// own block is finished
+	if g.sampling {
+		g.stopSampling()
+	}
+	if l.mode.Load() == autoUndecided {
+		l.finishEarly()
+	}
+	if l.mode.Load() != autoDynamic {
+		return g.doneIndex
+	}
+
+	// claim chunks from the blocks of other goroutines
+	numGR := len(l.blocks)
+	for k := 1; k < numGR; k++ {
+		if lo, hi, ok := l.blocks[(g.grID+k)%numGR].claim(l.chunkSize); ok {
+			return g.take(lo, hi)
+		}
+	}
+
+	return g.doneIndex
+}
+
+func (g *autoIndexGenerator) take(lo, hi int) int {
+	g.lo, g.hi = lo+1, hi
+	return lo
+}
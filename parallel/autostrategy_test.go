@@ -0,0 +1,174 @@
+package parallel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_StrategyAuto_WithVaryingSizes_VisitsEachIndexOnce(t *testing.T) {
+	for _, N := range []int{0, 5, 1000} {
+		for _, numGR := range []int{1, 3, 8} {
+			// arrange
+			e := parallel.WithStrategy(parallel.StrategyAuto).WithNumGoroutines(numGR)
+
+			for run := 0; run < 2; run++ {
+				visits := make([]int, N)
+				visitsWithContext := make([]int, N)
+
+				// act
+				e.For(N, func(i, _ int) {
+					if i%50 == 0 {
+						time.Sleep(100 * time.Microsecond)
+					}
+					visits[i]++
+				})
+				err := e.ForWithContext(context.Background(), N,
+					func(_ context.Context, i, _ int) {
+						visitsWithContext[i]++
+					})
+
+				// assert
+				if err != nil {
+					t.Errorf("expected nil error, received %v\n", err)
+				}
+				for i := 0; i < N; i++ {
+					if visits[i] != 1 || visitsWithContext[i] != 1 {
+						t.Errorf("(N=%d, %d threads) index %d visited %d and %d times\n",
+							N, numGR, i, visits[i], visitsWithContext[i])
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_AutoStrategy_BeforeAnyLoop_HasNoDecision(t *testing.T) {
+	// arrange
+	strategy := parallel.NewAutoStrategy()
+
+	// act
+	_, decided := strategy.LastDecision()
+
+	// assert
+	if decided {
+		t.Errorf("expected no decision before any loop\n")
+	}
+}
+
+func Test_AutoStrategy_WithFastUniformIterations_KeepsPreassignedBlocks(t *testing.T) {
+	// arrange
+	N := 100000
+	x := make([]float64, N)
+	strategy := parallel.NewAutoStrategy()
+	e := parallel.WithCustomStrategy(strategy).WithNumGoroutines(1)
+
+	// act
+	e.For(N, func(i, _ int) {
+		x[i] = float64(i) * 2
+	})
+	decision, decided := strategy.LastDecision()
+
+	// assert
+	if !decided || decision.Strategy != parallel.StrategyPreassignIndices {
+		t.Errorf("expected preassigned indices, received %+v\n", decision)
+	}
+	if decision.NumSamples == 0 {
+		t.Errorf("expected sampled iterations, received %+v\n", decision)
+	}
+}
+
+func Test_AutoStrategy_WithVaryingIterationTimes_SwitchesToDynamic(t *testing.T) {
+	// arrange
+	N := 200
+	strategy := parallel.NewAutoStrategy()
+	e := parallel.WithCustomStrategy(strategy).WithNumGoroutines(1)
+
+	// act
+	e.For(N, func(i, _ int) {
+		if i%4 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	})
+	decision, decided := strategy.LastDecision()
+
+	// assert
+	if !decided || decision.Strategy != parallel.StrategyFetchNextIndex {
+		t.Errorf("expected dynamic distribution, received %+v\n", decision)
+	}
+	if decision.Variation <= 1 {
+		t.Errorf("expected high variation, received %+v\n", decision)
+	}
+}
+
+func Test_AutoStrategy_WithSkewedBlocks_SwitchesToDynamic(t *testing.T) {
+	// arrange
+	N := 200
+	strategy := parallel.NewAutoStrategy()
+	e := parallel.WithCustomStrategy(strategy).WithNumGoroutines(2)
+
+	// act
+	e.For(N, func(i, _ int) {
+		if i < N/2 {
+			time.Sleep(time.Millisecond)
+		}
+	})
+	decision, decided := strategy.LastDecision()
+
+	// assert
+	if !decided || decision.Strategy != parallel.StrategyFetchNextIndex {
+		t.Errorf("expected dynamic distribution, received %+v\n", decision)
+	}
+}
+
+func Test_AutoStrategy_WithDirectIndexGenerators_GeneratesEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 100
+	numGR := 4
+	strategy := parallel.NewAutoStrategy()
+
+	for run := 0; run < 2; run++ {
+		visits := make([]int, N)
+
+		// act
+		generators := make([]parallel.IndexGenerator, numGR)
+		for grID := range generators {
+			generators[grID] = strategy.IndexGenerator(numGR, grID, N)
+		}
+		for _, g := range generators {
+			for i := g.Next(); i < N; i = g.Next() {
+				visits[i]++
+			}
+		}
+
+		// assert
+		for i, v := range visits {
+			if v != 1 {
+				t.Errorf("run %d) index %d generated %d times\n", run, i, v)
+				break
+			}
+		}
+	}
+}
+
+func ExampleNewAutoStrategy() {
+	strategy := parallel.NewAutoStrategy()
+	e := parallel.WithCustomStrategy(strategy).WithNumGoroutines(1)
+
+	// every fourth iteration is much slower than the rest
+	e.For(100, func(i, _ int) {
+		if i%4 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	})
+
+	decision, _ := strategy.LastDecision()
+	fmt.Println("dynamic:", decision.Strategy == parallel.StrategyFetchNextIndex)
+
+	// Output:
+	// dynamic: true
+}
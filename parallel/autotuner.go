@@ -0,0 +1,308 @@
+package parallel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// TunedConfig is an executor configuration explored by an AutoTuner.
+type TunedConfig struct {
+	// NumGoroutines is the number of goroutines used by the loop.
+	NumGoroutines int `json:"numGoroutines"`
+	// Strategy is either StrategyPreassignIndices or StrategyFetchNextIndex.
+	Strategy StrategyType `json:"strategy"`
+	// ChunkSize is the number of consecutive indices fetched at a time with
+	// StrategyFetchNextIndex. It is ignored with StrategyPreassignIndices.
+	ChunkSize int `json:"chunkSize,omitempty"`
+}
+
+// executor returns a new executor using the configuration.
+func (c TunedConfig) executor() *Executor {
+	strategy := UseStrategy(c.Strategy)
+	if c.Strategy == StrategyFetchNextIndex && c.ChunkSize > 1 {
+		strategy = UseCustomStrategy(newChunkedStrategy(c.ChunkSize))
+	}
+	return NewExecutor(NumGoroutines(c.NumGoroutines), strategy)
+}
+
+// AutoTunerOptions specifies the configurations explored by an AutoTuner.
+type AutoTunerOptions struct {
+	// GoroutineCounts are the numbers of goroutines to explore. The default is powers of two
+	// below the number of CPUs, and the number of CPUs.
+	GoroutineCounts []int
+	// ChunkSizes are the chunk sizes to explore with StrategyFetchNextIndex. The default is
+	// 1, 8 and 64.
+	ChunkSizes []int
+	// TrialsPerConfig is the number of times each configuration is measured during exploration.
+	// The default is 3.
+	TrialsPerConfig int
+	// ReexploreInterval is the number of calls using the best configuration after which all
+	// configurations are explored again, so that the tuner adapts to changes in the workload.
+	// The default is 1000. A negative value disables re-exploration.
+	ReexploreInterval int
+}
+
+// AutoTuner runs repeatedly executed loops, identified by name, and learns the fastest executor
+// configuration for each of them. Every configuration of goroutine count, strategy and chunk size
+// is first measured a few times. The configuration with the lowest mean wall time per iteration
+// is then used for subsequent calls, until the configurations are periodically explored again.
+// Because times are compared per iteration, the number of iterations may vary between calls of
+// the same loop, although loops of similar size tune best.
+//
+// Learned configurations may be exported with Export() and loaded with Import(), so that
+// deployments can reuse them without exploring again.
+//
+// An AutoTuner is safe for concurrent use by multiple goroutines.
+type AutoTuner struct {
+	candidates        []TunedConfig
+	trialsPerConfig   int
+	reexploreInterval int
+
+	mu    sync.Mutex
+	loops map[string]*tunedLoop
+}
+
+// tunedLoop is the tuning state of a single named loop.
+type tunedLoop struct {
+	// exploration state: the next trial to hand out, and mean time per iteration of each
+	// candidate
+	exploring  bool
+	nextTrial  int
+	numTrials  []int
+	timePerIts []float64
+
+	best           TunedConfig
+	converged      bool
+	callsSinceBest int
+}
+
+// NewAutoTuner returns a new AutoTuner that explores the configurations given by opts.
+func NewAutoTuner(opts AutoTunerOptions) *AutoTuner {
+	goroutineCounts := opts.GoroutineCounts
+	if len(goroutineCounts) == 0 {
+		numCPU := runtime.NumCPU()
+		for n := 1; n < numCPU; n *= 2 {
+			goroutineCounts = append(goroutineCounts, n)
+		}
+		goroutineCounts = append(goroutineCounts, numCPU)
+	}
+
+	chunkSizes := opts.ChunkSizes
+	if len(chunkSizes) == 0 {
+		chunkSizes = []int{1, 8, 64}
+	}
+
+	t := &AutoTuner{
+		trialsPerConfig:   opts.TrialsPerConfig,
+		reexploreInterval: opts.ReexploreInterval,
+		loops:             make(map[string]*tunedLoop),
+	}
+	if t.trialsPerConfig <= 0 {
+		t.trialsPerConfig = 3
+	}
+	if t.reexploreInterval == 0 {
+		t.reexploreInterval = 1000
+	}
+
+	for _, n := range goroutineCounts {
+		t.candidates = append(t.candidates, TunedConfig{
+			NumGoroutines: maxInt(n, 1),
+			Strategy:      StrategyPreassignIndices,
+		})
+		for _, chunkSize := range chunkSizes {
+			t.candidates = append(t.candidates, TunedConfig{
+				NumGoroutines: maxInt(n, 1),
+				Strategy:      StrategyFetchNextIndex,
+				ChunkSize:     maxInt(chunkSize, 1),
+			})
+		}
+	}
+
+	return t
+}
+
+// For executes the loop identified by name, in the same way as Executor.For(), using either a
+// configuration that is being explored or the best configuration found so far.
+func (t *AutoTuner) For(name string, N int, loopBody func(i, grID int)) {
+	candidate, config := t.nextConfig(name)
+
+	start := time.Now()
+	config.executor().For(N, loopBody)
+	t.record(name, candidate, float64(time.Since(start))/float64(maxInt(N, 1)))
+}
+
+// ForWithContext executes the loop identified by name, in the same way as
+// Executor.ForWithContext(), using either a configuration that is being explored or the best
+// configuration found so far. Calls that miss their deadline count as infinitely slow, so a
+// configuration that misses a deadline during exploration is never selected unless all
+// configurations do. Calls that end with any other error, such as cancellation by the caller, say
+// nothing about the configuration, so they are not measured and the trial is repeated.
+func (t *AutoTuner) ForWithContext(ctx context.Context, name string, N int,
+	loopBody func(ctx context.Context, i, grID int)) error {
+
+	candidate, config := t.nextConfig(name)
+
+	start := time.Now()
+	err := config.executor().ForWithContext(ctx, N, loopBody)
+	if err == nil {
+		t.record(name, candidate, float64(time.Since(start))/float64(maxInt(N, 1)))
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		t.record(name, candidate, math.Inf(1))
+	}
+
+	return err
+}
+
+// Best returns the best configuration found for the loop identified by name. The second return
+// value is false if the loop has not finished its first exploration and was not imported.
+func (t *AutoTuner) Best(name string) (TunedConfig, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	loop, found := t.loops[name]
+	if !found || !loop.converged {
+		return TunedConfig{}, false
+	}
+	return loop.best, true
+}
+
+// Export returns the best configurations of all loops that have converged as JSON, keyed by loop
+// name.
+func (t *AutoTuner) Export() ([]byte, error) {
+	t.mu.Lock()
+	configs := make(map[string]TunedConfig)
+	for name, loop := range t.loops {
+		if loop.converged {
+			configs[name] = loop.best
+		}
+	}
+	t.mu.Unlock()
+
+	return json.MarshalIndent(configs, "", "  ")
+}
+
+// Import loads configurations as exported by Export(). Imported loops use their configuration
+// immediately without exploring, until they are periodically explored again.
+func (t *AutoTuner) Import(data []byte) error {
+	var configs map[string]TunedConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for name, config := range configs {
+		loop := t.loop(name)
+		loop.best = config
+		loop.exploring = false
+		loop.converged = true
+		loop.callsSinceBest = 0
+	}
+
+	return nil
+}
+
+// loop returns the state of the loop identified by name, creating it if needed. t.mu must be
+// held.
+func (t *AutoTuner) loop(name string) *tunedLoop {
+	loop, found := t.loops[name]
+	if !found {
+		loop = &tunedLoop{
+			numTrials:  make([]int, len(t.candidates)),
+			timePerIts: make([]float64, len(t.candidates)),
+		}
+		t.loops[name] = loop
+	}
+	return loop
+}
+
+// nextConfig returns the configuration for the next call of a loop, along with the index of the
+// candidate being explored, or -1 if the best configuration is used.
+func (t *AutoTuner) nextConfig(name string) (int, TunedConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	loop := t.loop(name)
+
+	if !loop.exploring {
+		if loop.converged && (t.reexploreInterval < 0 ||
+			loop.callsSinceBest < t.reexploreInterval) {
+			loop.callsSinceBest++
+			return -1, loop.best
+		}
+
+		// explore with fresh measurements
+		loop.exploring = true
+		loop.nextTrial = 0
+		for i := range t.candidates {
+			loop.numTrials[i] = 0
+			loop.timePerIts[i] = 0
+		}
+	}
+
+	// interleave candidates so that slow changes in the environment affect all of them
+	candidate := 0
+	if loop.nextTrial < len(t.candidates)*t.trialsPerConfig {
+		candidate = loop.nextTrial % len(t.candidates)
+		loop.nextTrial++
+	} else {
+		// repeat trials that have not been measured yet, such as calls still in progress or calls
+		// that were cancelled
+		for i, numTrials := range loop.numTrials {
+			if numTrials < t.trialsPerConfig {
+				candidate = i
+				break
+			}
+		}
+	}
+
+	return candidate, t.candidates[candidate]
+}
+
+// record adds a measurement of the time per iteration of an explored candidate, and selects the
+// best configuration once all candidates have been measured.
+func (t *AutoTuner) record(name string, candidate int, timePerIt float64) {
+	if candidate < 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	loop := t.loop(name)
+	if !loop.exploring {
+		// measurement of a previous exploration
+		return
+	}
+	loop.numTrials[candidate]++
+	if math.IsInf(timePerIt, 1) || math.IsInf(loop.timePerIts[candidate], 1) {
+		// a missed deadline makes the candidate infinitely slow
+		loop.timePerIts[candidate] = math.Inf(1)
+	} else {
+		loop.timePerIts[candidate] += (timePerIt - loop.timePerIts[candidate]) /
+			float64(loop.numTrials[candidate])
+	}
+
+	for _, numTrials := range loop.numTrials {
+		if numTrials < t.trialsPerConfig {
+			return
+		}
+	}
+
+	best := 0
+	for i, timePerIt := range loop.timePerIts {
+		if timePerIt < loop.timePerIts[best] {
+			best = i
+		}
+	}
+	loop.best = t.candidates[best]
+	loop.exploring = false
+	loop.converged = true
+	loop.callsSinceBest = 0
+}
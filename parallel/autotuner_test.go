@@ -0,0 +1,275 @@
+package parallel_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_AutoTuner_WithRepeatedCalls_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 1000
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts:   []int{1, 4},
+		ChunkSizes:        []int{1, 16},
+		TrialsPerConfig:   2,
+		ReexploreInterval: 5,
+	})
+
+	for call := 0; call < 30; call++ {
+		visits := make([]int, N)
+		visitsWithContext := make([]int, N)
+
+		// act
+		tuner.For("loop", N, func(i, _ int) {
+			visits[i]++
+		})
+		err := tuner.ForWithContext(context.Background(), "loopWithContext", N,
+			func(_ context.Context, i, _ int) {
+				visitsWithContext[i]++
+			})
+
+		// assert
+		if err != nil {
+			t.Errorf("expected nil error, received %v\n", err)
+		}
+		for i := 0; i < N; i++ {
+			if visits[i] != 1 || visitsWithContext[i] != 1 {
+				t.Errorf("(call %d) index %d visited %d and %d times\n",
+					call, i, visits[i], visitsWithContext[i])
+				break
+			}
+		}
+	}
+}
+
+func Test_AutoTuner_AfterExploration_ConvergesToExploredConfig(t *testing.T) {
+	// arrange
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts: []int{1, 2},
+		ChunkSizes:      []int{1, 8},
+		TrialsPerConfig: 2,
+	})
+	numExploreCalls := 2 * 3 * 2 // goroutine counts * (preassign + chunk sizes) * trials
+	loopBody := func(_, _ int) {}
+
+	// act
+	for call := 0; call < numExploreCalls-1; call++ {
+		tuner.For("loop", 100, loopBody)
+	}
+	_, convergedBefore := tuner.Best("loop")
+	tuner.For("loop", 100, loopBody)
+	best, convergedAfter := tuner.Best("loop")
+
+	// assert
+	if convergedBefore {
+		t.Errorf("expected no best config before all candidates were measured\n")
+	}
+	if !convergedAfter {
+		t.Fatalf("expected best config after all candidates were measured\n")
+	}
+	if best.NumGoroutines != 1 && best.NumGoroutines != 2 {
+		t.Errorf("expected an explored goroutine count, received %+v\n", best)
+	}
+}
+
+func Test_AutoTuner_AfterReexploreInterval_ExploresAgain(t *testing.T) {
+	// arrange
+	N := 100
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts:   []int{3},
+		ChunkSizes:        []int{1},
+		TrialsPerConfig:   1,
+		ReexploreInterval: 2,
+	})
+	tuner.Import([]byte(`{"loop": {"numGoroutines": 1, "strategy": 0}}`))
+	maxGrIDs := []int{}
+
+	// act
+	for call := 0; call < 4; call++ {
+		maxGrID := 0
+		tuner.For("loop", N, func(_, grID int) {
+			if grID == 2 {
+				maxGrID = 2
+			}
+		})
+		maxGrIDs = append(maxGrIDs, maxGrID)
+	}
+
+	// assert
+	// imported config is used for 2 calls, then the preassigned candidate is explored
+	if maxGrIDs[0] != 0 || maxGrIDs[1] != 0 || maxGrIDs[2] != 2 {
+		t.Errorf("expected goroutines [0 0 2 ...], received %v\n", maxGrIDs)
+	}
+}
+
+func Test_AutoTuner_ExportThenImport_RestoresBestConfigs(t *testing.T) {
+	// arrange
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts: []int{2},
+		ChunkSizes:      []int{4},
+		TrialsPerConfig: 1,
+	})
+	tuner.For("a", 100, func(_, _ int) {})
+	tuner.For("a", 100, func(_, _ int) {})
+	tuner.For("unconverged", 100, func(_, _ int) {})
+	expected, _ := tuner.Best("a")
+
+	// act
+	data, exportErr := tuner.Export()
+	imported := parallel.NewAutoTuner(parallel.AutoTunerOptions{})
+	importErr := imported.Import(data)
+	actual, foundA := imported.Best("a")
+	_, foundUnconverged := imported.Best("unconverged")
+
+	// assert
+	if exportErr != nil || importErr != nil {
+		t.Fatalf("expected nil errors, received %v and %v\n", exportErr, importErr)
+	}
+	if !foundA || actual != expected {
+		t.Errorf("expected %+v, received %+v\n", expected, actual)
+	}
+	if foundUnconverged {
+		t.Errorf("expected unconverged loop to not be exported\n")
+	}
+}
+
+func Test_AutoTuner_WithFailingCalls_ConvergesToConfigThatSucceeded(t *testing.T) {
+	// arrange
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts:   []int{1, 2},
+		ChunkSizes:        []int{1},
+		TrialsPerConfig:   1,
+		ReexploreInterval: -1,
+	})
+	expired, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	loopBody := func(_ context.Context, _, _ int) {}
+
+	// act
+	// the first candidate, 1 goroutine with preassigned indices, misses its deadline
+	errs := []error{tuner.ForWithContext(expired, "loop", 10, loopBody)}
+	for call := 0; call < 3; call++ {
+		errs = append(errs, tuner.ForWithContext(context.Background(), "loop", 10, loopBody))
+	}
+	best, converged := tuner.Best("loop")
+
+	// assert
+	if errs[0] != context.DeadlineExceeded || errs[1] != nil || errs[2] != nil || errs[3] != nil {
+		t.Errorf("expected only the first call to fail, received %v\n", errs)
+	}
+	if !converged {
+		t.Fatalf("expected convergence after every candidate was tried\n")
+	}
+	failed := parallel.TunedConfig{NumGoroutines: 1, Strategy: parallel.StrategyPreassignIndices}
+	if best == failed {
+		t.Errorf("expected a config other than the failed one, received %+v\n", best)
+	}
+}
+
+func Test_AutoTuner_WithAllCallsFailing_StillConverges(t *testing.T) {
+	// arrange
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts: []int{1, 8},
+		ChunkSizes:      []int{1},
+		TrialsPerConfig: 2,
+	})
+	expired, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+
+	// act
+	for call := 0; call < 2*2*2; call++ {
+		tuner.ForWithContext(expired, "loop", 10, func(_ context.Context, _, _ int) {})
+	}
+	_, converged := tuner.Best("loop")
+
+	// assert
+	if !converged {
+		t.Errorf("expected convergence after every trial was tried\n")
+	}
+}
+
+func Test_AutoTuner_WithCanceledTrialOfBestConfig_RepeatsTrialAndSelectsIt(t *testing.T) {
+	// arrange
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{
+		GoroutineCounts:   []int{1, 8},
+		ChunkSizes:        []int{8},
+		TrialsPerConfig:   1,
+		ReexploreInterval: -1,
+	})
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	// iterations sleep, so that only 8 goroutines with preassigned indices run them in parallel
+	loopBody := func(_ context.Context, _, _ int) {
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// act
+	// the third candidate, 8 goroutines with preassigned indices, is canceled by the caller
+	errs := make([]error, 5)
+	for call := range errs {
+		ctx := context.Background()
+		if call == 2 {
+			ctx = canceled
+		}
+		errs[call] = tuner.ForWithContext(ctx, "loop", 8, loopBody)
+	}
+	best, converged := tuner.Best("loop")
+
+	// assert
+	for call, err := range errs {
+		if (call == 2) != (err == context.Canceled) || (call != 2 && err != nil) {
+			t.Errorf("call %d) unexpected error %v\n", call, err)
+		}
+	}
+	if !converged {
+		t.Fatalf("expected convergence after the canceled trial was repeated\n")
+	}
+	expected := parallel.TunedConfig{NumGoroutines: 8, Strategy: parallel.StrategyPreassignIndices}
+	if best != expected {
+		t.Errorf("expected %+v, received %+v\n", expected, best)
+	}
+}
+
+func Test_AutoTuner_WithInvalidJSON_ReturnsError(t *testing.T) {
+	// arrange
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{})
+
+	// act
+	err := tuner.Import([]byte("{"))
+
+	// assert
+	if err == nil {
+		t.Errorf("expected error on invalid JSON\n")
+	}
+}
+
+func Test_AutoTuner_WithConcurrentCalls_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 200
+	numCallers := 4
+	tuner := parallel.NewAutoTuner(parallel.AutoTunerOptions{TrialsPerConfig: 1})
+	visits := make([][]int, numCallers)
+
+	// act
+	parallel.WithNumGoroutines(numCallers).For(numCallers, func(caller, _ int) {
+		visits[caller] = make([]int, N)
+		for call := 0; call < 10; call++ {
+			tuner.For("shared", N, func(i, _ int) {
+				visits[caller][i]++
+			})
+		}
+	})
+
+	// assert
+	for caller := range visits {
+		for i, v := range visits[caller] {
+			if v != 10 {
+				t.Errorf("caller %d, index %d visited %d times\n", caller, i, v)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package parallel
+
+import (
+	"sync"
+)
+
+// barrier synchronises a fixed number of goroutines between the phases of an iterative
+// computation.
+type barrier struct {
+	mu         sync.Mutex
+	cond       *sync.Cond
+	numGR      int
+	numWaiting int
+	generation uint64
+}
+
+func newBarrier(numGR int) *barrier {
+	b := &barrier{numGR: numGR}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// await blocks until all goroutines have called await. The last goroutine to arrive runs action,
+// if not nil, before any of the goroutines are released, so action may safely update state that
+// is read by all goroutines in the next phase.
+func (b *barrier) await(action func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	generation := b.generation
+	b.numWaiting++
+
+	if b.numWaiting == b.numGR {
+		if action != nil {
+			action()
+		}
+		b.numWaiting = 0
+		b.generation++
+		b.cond.Broadcast()
+		return
+	}
+
+	for generation == b.generation {
+		b.cond.Wait()
+	}
+}
+
+// region executes body once on each of numGR goroutines, and returns once all have returned.
+// Callers limit numGR with loopGoroutines() in the same way as For(). Unlike For(), the same
+// goroutines persist for the whole region and may synchronise through the barrier, which avoids
+// spawning goroutines for each phase of an iterative computation. All goroutines are spawned,
+// rather than the caller acting as goroutine 0, since a panic on the caller would leave the others
+// waiting at the barrier.
+func (e *Executor) region(numGR int, body func(grID int, b *barrier)) {
+	b := newBarrier(numGR)
+
+	var wg sync.WaitGroup
+	wg.Add(numGR)
+
+	for grID := 0; grID < numGR; grID++ {
+		go func(grID int) {
+			defer wg.Done()
+			body(grID, b)
+		}(grID)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,43 @@
+package parallel
+
+// forEachBlock splits N work items into numBlocks contiguous index blocks and executes blockBody
+// once per block. Blocks are distributed among goroutines by BlockExecutor(), and the indices
+// within a block are always visited by a single goroutine in ascending order.
+func (e *Executor) forEachBlock(numBlocks, N int, blockBody func(block, start, stop, grID int)) {
+	e.BlockExecutor().For(numBlocks, func(block, grID int) {
+		start, stop := grIndexBlock(numBlocks, block, N)
+		blockBody(block, start, stop, grID)
+	})
+}
+
+// concatBlocks concatenates per-block buffers into a single slice, preserving block order.
+// Output offsets are computed from a prefix sum of the buffer lengths so that each buffer may be
+// copied into place independently.
+func concatBlocks[T any](e *Executor, buffers [][]T) []T {
+	offsets := make([]int, len(buffers)+1)
+	for block, buffer := range buffers {
+		offsets[block+1] = offsets[block] + len(buffer)
+	}
+
+	out := make([]T, offsets[len(buffers)])
+	e.BlockExecutor().For(len(buffers), func(block, _ int) {
+		copy(out[offsets[block]:], buffers[block])
+	})
+
+	return out
+}
+
+// mergeTree combines n partial results into partial result 0 by merging pairs of results in
+// rounds, where merge(dst, src) must fold partial result src into partial result dst. In each
+// round, the merges are executed in parallel, and src always follows dst, so results are
+// combined in ascending order.
+func (e *Executor) mergeTree(n int, merge func(dst, src int)) {
+	blockExecutor := e.BlockExecutor()
+	for stride := 1; stride < n; stride *= 2 {
+		numMerges := (n - stride + 2*stride - 1) / (2 * stride)
+		blockExecutor.For(numMerges, func(m, _ int) {
+			dst := 2 * stride * m
+			merge(dst, dst+stride)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package parallel
+
+import (
+	"sync/atomic"
+)
+
+// chunkedStrategy is a variant of the atomic counter strategy where goroutines fetch chunks of
+// consecutive work indices, which reduces contention on the counter for short loop iterations.
+type chunkedStrategy struct {
+	chunkSize int64
+	counter   int64
+}
+
+func newChunkedStrategy(chunkSize int) Strategy {
+	return &chunkedStrategy{
+		chunkSize: int64(maxInt(chunkSize, 1)),
+	}
+}
+
+func (s *chunkedStrategy) newLoop() Strategy {
+	return newChunkedStrategy(int(s.chunkSize))
+}
+
+func (s *chunkedStrategy) IndexGenerator(_, _, N int) IndexGenerator {
+	return &chunkedIndexGenerator{
+		strategy:  s,
+		doneIndex: N,
+	}
+}
+
+type chunkedIndexGenerator struct {
+	strategy  *chunkedStrategy
+	doneIndex int
+
+	nextIndex, stopIndex int
+}
+
+func (g *chunkedIndexGenerator) Next() int {
+	if g.nextIndex >= g.stopIndex {
+		chunkSize := g.strategy.chunkSize
+		startIndex := atomic.AddInt64(&g.strategy.counter, chunkSize) - chunkSize
+		if startIndex >= int64(g.doneIndex) {
+			return g.doneIndex
+		}
+		g.nextIndex = int(startIndex)
+		g.stopIndex = minInt(int(startIndex+chunkSize), g.doneIndex)
+	}
+
+	thisIndex := g.nextIndex
+	g.nextIndex++
+
+	return thisIndex
+}
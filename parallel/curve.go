@@ -0,0 +1,175 @@
+package parallel
+
+// Curve identifies an order in which to visit the cells of a two-dimensional grid.
+type Curve int
+
+const (
+	// CurveRowMajor visits cells row by row, in ascending x within each row.
+	CurveRowMajor = Curve(iota)
+
+	// CurveMorton visits cells along a Morton (Z-order) curve, which recursively visits the four
+	// quadrants of the grid in a Z pattern.
+	CurveMorton = Curve(iota)
+
+	// CurveHilbert visits cells along a Hilbert curve, where consecutive cells are adjacent.
+	// Grids whose sides are not powers of two use a generalized Hilbert curve, which may take a
+	// single diagonal step when one side of the grid is odd and the other is even.
+	CurveHilbert = Curve(iota)
+)
+
+// NewCurveStrategy returns a strategy for loops over the cells of a width x height grid, where the
+// work index of the cell at column x and row y is y*width + x. Cells are visited in the order of
+// the given curve, so that each block of consecutive positions along the curve covers a compact
+// region of the grid. Loops using the strategy must have exactly width*height iterations.
+// The strategyType determines how positions along the curve are distributed among goroutines:
+// StrategyFetchNextIndex hands out positions through a shared counter, while
+// StrategyPreassignIndices and any other value preassign each goroutine a contiguous section of
+// the curve.
+// The returned strategy is used with WithCustomStrategy().
+func NewCurveStrategy(width, height int, curve Curve, strategyType StrategyType) Strategy {
+	order := curveOrder(width, height, curve)
+	if order == nil {
+		order = make([]int, maxInt(width*height, 0))
+		for i := range order {
+			order[i] = i
+		}
+	}
+
+	return newPermutedStrategy(userOrder(order), strategyType)
+}
+
+// curveOrder returns the row-major cell indices of a width x height grid in the order visited by
+// curve, or nil if curve visits cells in row-major order.
+func curveOrder(width, height int, curve Curve) []int {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	switch curve {
+	case CurveMorton:
+		return mortonOrder(width, height)
+	case CurveHilbert:
+		return hilbertOrder(width, height)
+	default:
+		return nil
+	}
+}
+
+// mortonOrder visits the enclosing power-of-two rectangle in Morton order, skipping cells that
+// lie outside of the grid.
+func mortonOrder(width, height int) []int {
+	xBits, yBits := ceilLog2(width), ceilLog2(height)
+	order := make([]int, 0, width*height)
+
+	for d := 0; d < 1<<(xBits+yBits); d++ {
+		// de-interleave bits of d, alternating x and y while both have bits remaining
+		x, y := 0, 0
+		xBit, yBit := 0, 0
+		for bit := 0; bit < xBits+yBits; bit++ {
+			v := (d >> bit) & 1
+			if xBit < xBits && (xBit <= yBit || yBit >= yBits) {
+				x |= v << xBit
+				xBit++
+			} else {
+				y |= v << yBit
+				yBit++
+			}
+		}
+
+		if x < width && y < height {
+			order = append(order, y*width+x)
+		}
+	}
+
+	return order
+}
+
+func ceilLog2(n int) int {
+	bits := 0
+	for 1<<bits < n {
+		bits++
+	}
+	return bits
+}
+
+// hilbertOrder generates a generalized Hilbert curve, which fills rectangles of any size while
+// keeping consecutive cells adjacent.
+func hilbertOrder(width, height int) []int {
+	order := make([]int, 0, width*height)
+	visit := func(x, y int) {
+		order = append(order, y*width+x)
+	}
+
+	if width >= height {
+		generalizedHilbert(0, 0, width, 0, 0, height, visit)
+	} else {
+		generalizedHilbert(0, 0, 0, height, width, 0, visit)
+	}
+
+	return order
+}
+
+// generalizedHilbert visits the rectangle with corner (x, y), major axis (ax, ay) and minor axis
+// (bx, by), following the generalized Hilbert ("gilbert") construction of Jakub Červený.
+func generalizedHilbert(x, y, ax, ay, bx, by int, visit func(x, y int)) {
+	w, h := absInt(ax+ay), absInt(bx+by)
+	dax, day := signInt(ax), signInt(ay) // unit major direction
+	dbx, dby := signInt(bx), signInt(by) // unit minor direction
+
+	if h == 1 {
+		for i := 0; i < w; i++ {
+			visit(x, y)
+			x, y = x+dax, y+day
+		}
+		return
+	}
+	if w == 1 {
+		for i := 0; i < h; i++ {
+			visit(x, y)
+			x, y = x+dbx, y+dby
+		}
+		return
+	}
+
+	// halve axes, rounding towards negative infinity
+	ax2, ay2 := ax>>1, ay>>1
+	bx2, by2 := bx>>1, by>>1
+	w2, h2 := absInt(ax2+ay2), absInt(bx2+by2)
+
+	if 2*w > 3*h {
+		// split the long rectangle in two along the major axis
+		if w2%2 != 0 && w > 2 {
+			ax2, ay2 = ax2+dax, ay2+day
+		}
+		generalizedHilbert(x, y, ax2, ay2, bx, by, visit)
+		generalizedHilbert(x+ax2, y+ay2, ax-ax2, ay-ay2, bx, by, visit)
+		return
+	}
+
+	// standard case: one step up, one long horizontal step, one step down
+	if h2%2 != 0 && h > 2 {
+		bx2, by2 = bx2+dbx, by2+dby
+	}
+	generalizedHilbert(x, y, bx2, by2, ax2, ay2, visit)
+	generalizedHilbert(x+bx2, y+by2, ax, ay, bx-bx2, by-by2, visit)
+	generalizedHilbert(x+(ax-dax)+(bx2-dbx), y+(ay-day)+(by2-dby),
+		-bx2, -by2, -(ax - ax2), -(ay - ay2), visit)
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func signInt(a int) int {
+	switch {
+	case a < 0:
+		return -1
+	case a > 0:
+		return 1
+	default:
+		return 0
+	}
+}
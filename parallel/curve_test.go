@@ -0,0 +1,171 @@
+package parallel_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func visitOrder2D(width, height int, curve parallel.Curve) [][2]int {
+	var cells [][2]int
+	parallel.WithNumGoroutines(1).For2D(width, height, curve, func(x, y, _ int) {
+		cells = append(cells, [2]int{x, y})
+	})
+	return cells
+}
+
+func Test_For2D_WithVaryingCurvesAndSizes_VisitsEachCellOnce(t *testing.T) {
+	sizes := [][2]int{{1, 1}, {1, 9}, {7, 1}, {8, 8}, {5, 3}, {3, 5}, {17, 6}, {6, 17}, {31, 33}}
+	curves := []parallel.Curve{parallel.CurveRowMajor, parallel.CurveMorton, parallel.CurveHilbert}
+
+	for _, size := range sizes {
+		for _, curve := range curves {
+			width, height := size[0], size[1]
+			visits := make([]int, width*height)
+
+			// act
+			parallel.WithNumGoroutines(3).For2D(width, height, curve, func(x, y, _ int) {
+				visits[y*width+x]++
+			})
+
+			// assert
+			for i, v := range visits {
+				if v != 1 {
+					t.Errorf("%dx%d, curve %d) cell %d visited %d times\n",
+						width, height, curve, i, v)
+					break
+				}
+			}
+		}
+	}
+}
+
+func Test_For2D_WithMortonCurve_VisitsInZOrder(t *testing.T) {
+	// arrange
+	expected := "[[0 0] [1 0] [0 1] [1 1] [2 0] [3 0] [2 1] [3 1] " +
+		"[0 2] [1 2] [0 3] [1 3] [2 2] [3 2] [2 3] [3 3]]"
+
+	// act
+	actual := fmt.Sprint(visitOrder2D(4, 4, parallel.CurveMorton))
+
+	// assert
+	if expected != actual {
+		t.Errorf("expected %s, actual %s\n", expected, actual)
+	}
+}
+
+func Test_For2D_WithHilbertCurve_VisitsAdjacentCellsConsecutively(t *testing.T) {
+	for width := 1; width <= 24; width++ {
+		for height := 1; height <= 24; height++ {
+			// act
+			cells := visitOrder2D(width, height, parallel.CurveHilbert)
+
+			// assert
+			numDiagonal := 0
+			for k := 1; k < len(cells); k++ {
+				dx, dy := cells[k][0]-cells[k-1][0], cells[k][1]-cells[k-1][1]
+				switch dx*dx + dy*dy {
+				case 1:
+				case 2:
+					numDiagonal++
+				default:
+					t.Errorf("%dx%d) cells %v and %v are not adjacent\n",
+						width, height, cells[k-1], cells[k])
+				}
+			}
+
+			// a single diagonal step is allowed only when one side is odd and the other even
+			maxDiagonal := 0
+			if width%2 != height%2 {
+				maxDiagonal = 1
+			}
+			if numDiagonal > maxDiagonal {
+				t.Errorf("%dx%d) expected at most %d diagonal steps, received %d\n",
+					width, height, maxDiagonal, numDiagonal)
+			}
+		}
+	}
+}
+
+func Test_For2D_WithHilbertCurve_AssignsCompactRegionPerGoroutine(t *testing.T) {
+	// arrange
+	width, height := 16, 16
+	minX, minY := []int{99, 99, 99, 99}, []int{99, 99, 99, 99}
+	maxX, maxY := []int{-1, -1, -1, -1}, []int{-1, -1, -1, -1}
+	grIDs := make([]int, width*height)
+
+	// act
+	parallel.WithNumGoroutines(4).For2D(width, height, parallel.CurveHilbert, func(x, y, grID int) {
+		grIDs[y*width+x] = grID
+	})
+
+	// assert
+	for i, grID := range grIDs {
+		x, y := i%width, i/width
+		minX[grID], maxX[grID] = minInt(minX[grID], x), maxInt(maxX[grID], x)
+		minY[grID], maxY[grID] = minInt(minY[grID], y), maxInt(maxY[grID], y)
+	}
+	for grID := 0; grID < 4; grID++ {
+		if maxX[grID]-minX[grID] != 7 || maxY[grID]-minY[grID] != 7 {
+			t.Errorf("goroutine %d) expected 8x8 quadrant, actual x in [%d, %d], y in [%d, %d]\n",
+				grID, minX[grID], maxX[grID], minY[grID], maxY[grID])
+		}
+	}
+}
+
+func Test_CurveStrategy_WithFetchNextIndex_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	width, height := 13, 7
+	strategy := parallel.NewCurveStrategy(width, height, parallel.CurveHilbert,
+		parallel.StrategyFetchNextIndex)
+	visits := make([]int32, width*height)
+
+	// act
+	parallel.WithCustomStrategy(strategy).WithNumGoroutines(4).For(width*height, func(i, _ int) {
+		visits[i]++
+	})
+
+	// assert
+	for i, v := range visits {
+		if v != 1 {
+			t.Errorf("index %d visited %d times\n", i, v)
+		}
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func ExampleFor2D() {
+	width, height := 4, 4
+	grIDs := make([][]int, height)
+	for y := range grIDs {
+		grIDs[y] = make([]int, width)
+	}
+
+	// each goroutine processes one quadrant of the grid
+	parallel.WithNumGoroutines(4).For2D(width, height, parallel.CurveMorton, func(x, y, grID int) {
+		grIDs[y][x] = grID
+	})
+
+	for _, row := range grIDs {
+		fmt.Println(row)
+	}
+	// Output:
+	// [0 0 1 1]
+	// [0 0 1 1]
+	// [2 2 3 3]
+	// [2 2 3 3]
+}
@@ -2,67 +2,112 @@ package parallel
 
 import (
 	"context"
-	"math"
-	"runtime"
 	"sync"
 )
 
 // Executor is the core type used to execute parallel loops.
-// New instances are created using NewExecutor().
+// New instances are created using NewExecutor(). The zero value is also usable, and executes
+// loops on a single goroutine with the default strategies.
+//
+// Executors are immutable once created: the With...() methods return a modified copy of the
+// executor and leave the receiver unchanged, so variants may be derived from a shared executor
+// without affecting its other users. A single executor is safe to use from multiple goroutines
+// concurrently, provided that any custom strategy is safe for concurrent IndexGenerator() calls.
 type Executor struct {
-	numGoroutines    int
-	parallelStrategy Strategy
+	numGoroutines        int
+	minItemsPerGoroutine int
+	checkIndices         bool
+	parallelStrategy     Strategy
 }
 
-// NewExecutor returns a new parallel executor instance.
-func NewExecutor() *Executor {
+// NewExecutor returns a new parallel executor instance, configured by the given options.
+// Without options, the executor uses the default number of goroutines and the default strategies.
+//
+//		e := parallel.NewExecutor(parallel.NumGoroutines(8),
+//			parallel.UseStrategy(parallel.StrategyFetchNextIndex))
+func NewExecutor(opts ...Option) *Executor {
 	e := new(Executor)
 	e.numGoroutines = DefaultNumGoroutines()
+	for _, opt := range opts {
+		opt(e)
+	}
 	return e
 }
 
-// NumGoroutines returns the number of goroutines that an executor is configured to use.
+// NumGoroutines returns the number of goroutines that an executor is configured to use, which is
+// at least 1.
 func (e *Executor) NumGoroutines() int {
-	return e.numGoroutines
+	return maxInt(e.numGoroutines, 1)
+}
+
+// Clone returns a copy of the executor with the same configuration.
+func (e *Executor) Clone() *Executor {
+	clone := *e
+	return &clone
 }
 
-// WithNumGoroutines sets the number of goroutines for a parallel executor to use.
+// With returns a copy of the executor with the given options applied.
+func (e *Executor) With(opts ...Option) *Executor {
+	clone := e.Clone()
+	for _, opt := range opts {
+		opt(clone)
+	}
+	return clone
+}
+
+// WithNumGoroutines returns a copy of the executor that uses a specific number of goroutines.
+// Values less than 1 are clamped to 1.
 func (e *Executor) WithNumGoroutines(numGoroutines int) *Executor {
-	e.numGoroutines = numGoroutines
-	return e
+	return e.With(NumGoroutines(numGoroutines))
 }
 
-// WithCPUProportion sets the number of goroutines based on a proportion of number of cores,
-// with a minimum of 1.
+// WithCPUProportion returns a copy of the executor with the number of goroutines based on a
+// proportion of number of cores, with a minimum of 1. Proportions that are NaN or infinite also
+// result in 1 goroutine.
 func (e *Executor) WithCPUProportion(p float64) *Executor {
-	numCPU := runtime.NumCPU()
-	pCPU := p * float64(numCPU)
-	e.numGoroutines = int(math.Max(pCPU, 1.0))
-	return e
+	return e.With(CPUProportion(p))
+}
+
+// WithIndexChecking returns a copy of the executor with index checking enabled or disabled. See
+// IndexChecking() for details.
+func (e *Executor) WithIndexChecking(check bool) *Executor {
+	return e.With(IndexChecking(check))
+}
+
+// WithMinItemsPerGoroutine returns a copy of the executor with a minimum number of loop
+// iterations per goroutine. See MinItemsPerGoroutine() for details.
+func (e *Executor) WithMinItemsPerGoroutine(grain int) *Executor {
+	return e.With(MinItemsPerGoroutine(grain))
 }
 
-// WithStrategy sets the parallel strategy for execution.
+// WithStrategy returns a copy of the executor with a particular parallel strategy for execution.
 // Different parallel strategies vary on how work items are distributed among goroutines.
 // If either StrategyUseDefaults or an unrecognized value is specified, the
 // defaults will be used for both For() and ForWithContext().
 func (e *Executor) WithStrategy(strategyType StrategyType) *Executor {
-	switch strategyType {
-	case StrategyPreassignIndices:
-		e.parallelStrategy = newContiguousBlocksStrategy()
-	case StrategyFetchNextIndex:
-		e.parallelStrategy = newAtomicCounterStrategy()
-	default:
-		e.parallelStrategy = nil
-	}
-	return e
+	return e.With(UseStrategy(strategyType))
 }
 
-// WithCustomStrategy sets a custom parallel strategy for execution.
+// WithCustomStrategy returns a copy of the executor with a custom parallel strategy for execution.
 // Defining custom strategies is an advanced feature. Most users should instead specify one of the
 // strategies built into this package using WithStrategy().
 func (e *Executor) WithCustomStrategy(customStrategy Strategy) *Executor {
-	e.parallelStrategy = customStrategy
-	return e
+	return e.With(UseCustomStrategy(customStrategy))
+}
+
+// BlockExecutor returns the executor to use for loops over blocks, chunks or tiles of work rather
+// than over the N work items that the executor's strategy was chosen for. Custom strategies may be
+// tied to a particular number of iterations, such as the order given to NewOrderStrategy(), so
+// the returned executor uses the default strategies in their place. Executors using
+// StrategyPreassignIndices or StrategyFetchNextIndex are returned unchanged. Packages built on this one use BlockExecutor() for their
+// internal loops, and so do the helpers of this package, such as Filter() and Sort().
+func (e *Executor) BlockExecutor() *Executor {
+	switch e.parallelStrategy.(type) {
+	case nil, *contiguousBlocksStrategy, *atomicCounterStrategy:
+		return e
+	default:
+		return e.With(UseStrategy(StrategyUseDefaults))
+	}
 }
 
 // For executes N iterations of a function body, where the iterations are parallelized among a
@@ -82,70 +127,191 @@ func (e *Executor) WithCustomStrategy(customStrategy Strategy) *Executor {
 // be computed more quickly from the partial results immediately after the parallel loop.
 //
 // By default, For() uses the contiguous index blocks strategy.
+// If N <= 0, For() returns immediately without executing the loop body.
+//
+// The calling goroutine executes the iterations of goroutine ID 0. No more than one goroutine is
+// used per iteration, or per grain size if set with WithMinItemsPerGoroutine(), so small loops use
+// fewer goroutines than NumGoroutines, and loops that only warrant one goroutine are executed
+// serially without starting any goroutines.
+// If an iteration executed by the calling goroutine panics, the panic is propagated once the
+// other goroutines have finished.
 func (e *Executor) For(N int, loopBody func(i, grID int)) {
-	// use default contiguous blocks strategy if strategy has not been specified on executor
-	strategy := e.parallelStrategy
-	if strategy == nil {
-		strategy = newContiguousBlocksStrategy()
+	numGR := e.loopGoroutines(N)
+	if numGR == 0 {
+		return
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(e.numGoroutines)
+	// use default contiguous blocks strategy if strategy has not been specified on executor
+	strategy := e.loopStrategy(newContiguousBlocksStrategy)
+	checker := e.indexChecker(N)
 
-	for grID := 0; grID < e.numGoroutines; grID++ {
-		go func(grID int) {
-			defer wg.Done()
-			// make index generator for this goroutine
-			indexGenerator := strategy.IndexGenerator(e.numGoroutines, grID, N)
-			// fetch work indices until work is complete
-			for i := indexGenerator.Next(); i < N; i = indexGenerator.Next() {
-				loopBody(i, grID)
-			}
-		}(grID)
-	}
+	e.run(numGR, func(grID int) {
+		// make index generator for this goroutine
+		indexGenerator := e.indexGenerator(strategy, checker, numGR, grID, N)
+		// fetch work indices until work is complete
+		for i := indexGenerator.Next(); i < N; i = indexGenerator.Next() {
+			loopBody(i, grID)
+		}
+	})
 
-	wg.Wait()
+	if checker != nil {
+		checker.verify(false)
+	}
 }
 
 // ForWithContext is the same as For(), but includes a context argument to enable timeout,
 // cancellation, and other context capabilities.
 // By default, ForWithContext() uses the atomic counter strategy instead of contiguous index
 // blocks. The corresponding ctx.Err() is returned, and will be nil if the loop completed
-// successfully. The context ctx is propagated directly to loop iterations. This context is also
-// checked between loop iterations, so long-running loops will exit prior to completion if ctx is
-// ended, even if ctx is unused within the loop body.
+// successfully. If N <= 0, the loop body is not executed and ctx.Err() is returned immediately.
+// The context ctx is propagated directly to loop iterations. This context is also checked between
+// loop iterations, so long-running loops will exit prior to completion if ctx is ended, even if
+// ctx is unused within the loop body.
 //
 // On loops that do not require the use of context, For() is recommended as it is slightly faster.
 func (e *Executor) ForWithContext(ctx context.Context, N int,
 	loopBody func(ctx context.Context, i, grID int)) error {
 
+	numGR := e.loopGoroutines(N)
+	if numGR == 0 {
+		return ctx.Err()
+	}
+
 	// use default atomic counter strategy if strategy has not been specified on executor
-	strategy := e.parallelStrategy
-	if strategy == nil {
-		strategy = newAtomicCounterStrategy()
+	strategy := e.loopStrategy(newAtomicCounterStrategy)
+	checker := e.indexChecker(N)
+
+	e.run(numGR, func(grID int) {
+		// make index generator for this goroutine
+		indexGenerator := e.indexGenerator(strategy, checker, numGR, grID, N)
+		// fetch work indices until work is complete
+		for i := indexGenerator.Next(); i < N; i = indexGenerator.Next() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				loopBody(ctx, i, grID)
+			}
+		}
+	})
+
+	err := ctx.Err()
+	if checker != nil {
+		checker.verify(err != nil)
 	}
 
+	return err
+}
+
+// ForWithContextSkipped is the same as ForWithContext(), but also returns the indices, in ascending
+// order, of the iterations that were skipped because ctx ended before they were started.
+// Iterations that started before ctx ended are not reported, even if they observed ctx ending.
+// The returned slice is empty if the loop completed successfully.
+//
+// Combined with NewPriorityStrategy() or NewDeadlineStrategy(), the most important iterations are
+// started first, so the skipped iterations are the least important ones.
+func (e *Executor) ForWithContextSkipped(ctx context.Context, N int,
+	loopBody func(ctx context.Context, i, grID int)) ([]int, error) {
+
+	started := make([]bool, maxInt(N, 0))
+
+	err := e.ForWithContext(ctx, N, func(ctx context.Context, i, grID int) {
+		started[i] = true
+		loopBody(ctx, i, grID)
+	})
+
+	skipped := []int{}
+	for i, s := range started {
+		if !s {
+			skipped = append(skipped, i)
+		}
+	}
+
+	return skipped, err
+}
+
+// For2D executes a loop body for every cell of a width x height grid, where the cells are visited
+// in the order of the given curve. The first two arguments to the loop body are the column x and
+// row y of the cell, and the third is the ID of the goroutine executing the loop iteration.
+// Positions along the curve are distributed among goroutines by the executor's strategy, in the
+// same way as work indices in For(). With the default contiguous blocks strategy, a Morton or
+// Hilbert curve gives each goroutine a compact region of the grid, which improves locality for
+// spatial workloads.
+func (e *Executor) For2D(width, height int, curve Curve, loopBody func(x, y, grID int)) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	order := curveOrder(width, height, curve)
+
+	e.For(width*height, func(position, grID int) {
+		i := position
+		if order != nil {
+			i = order[position]
+		}
+		loopBody(i%width, i/width, grID)
+	})
+}
+
+// loopGoroutines returns the number of goroutines to use for a loop of N iterations, which is
+// limited to one goroutine per grain size.
+func (e *Executor) loopGoroutines(N int) int {
+	if N <= 0 {
+		return 0
+	}
+	grain := maxInt(e.minItemsPerGoroutine, 1)
+	return minInt(e.NumGoroutines(), (N+grain-1)/grain)
+}
+
+// run executes a worker function for each goroutine ID in [0, numGR), where the calling goroutine
+// acts as goroutine 0 and the others are started as new goroutines.
+func (e *Executor) run(numGR int, worker func(grID int)) {
 	var wg sync.WaitGroup
-	wg.Add(e.numGoroutines)
+	wg.Add(numGR - 1)
+	// wait for the other goroutines even if worker 0 panics, so that no loop iterations are still
+	// running once the panic reaches the caller
+	defer wg.Wait()
 
-	for grID := 0; grID < e.numGoroutines; grID++ {
+	for grID := 1; grID < numGR; grID++ {
 		go func(grID int) {
 			defer wg.Done()
-			// make index generator for this goroutine
-			indexGenerator := strategy.IndexGenerator(e.numGoroutines, grID, N)
-			// fetch work indices until work is complete
-			for i := indexGenerator.Next(); i < N; i = indexGenerator.Next() {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					loopBody(ctx, i, grID)
-				}
-			}
+			worker(grID)
 		}(grID)
 	}
 
-	wg.Wait()
+	worker(0)
+}
+
+// indexChecker returns a new index checker for a loop of N iterations, or nil if index checking
+// is disabled.
+func (e *Executor) indexChecker(N int) *indexChecker {
+	if !e.checkIndices {
+		return nil
+	}
+	return newIndexChecker(N)
+}
+
+// indexGenerator creates the index generator of a goroutine, which is checked if checker is not
+// nil.
+func (e *Executor) indexGenerator(strategy Strategy, checker *indexChecker,
+	numGR, grID, N int) IndexGenerator {
+
+	indexGenerator := strategy.IndexGenerator(numGR, grID, N)
+	if checker != nil {
+		return checker.generator(indexGenerator, grID)
+	}
+	return indexGenerator
+}
 
-	return ctx.Err()
+// loopStrategy returns the strategy to use for a single loop execution. If no strategy has been
+// specified on the executor, a new instance of the default strategy is returned.
+func (e *Executor) loopStrategy(defaultStrategy func() Strategy) Strategy {
+	switch strategy := e.parallelStrategy.(type) {
+	case nil:
+		return defaultStrategy()
+	case perLoopStrategy:
+		return strategy.newLoop()
+	default:
+		return strategy
+	}
 }
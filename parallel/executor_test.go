@@ -1,8 +1,11 @@
 package parallel_test
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/dgravesa/go-parallel/parallel"
 )
@@ -131,3 +134,223 @@ func Test_Executor_NumGoroutines_ReturnsExpectedResult(t *testing.T) {
 		t.Errorf("expected %d, actual %d\n", expected, actual)
 	}
 }
+
+func Test_ExecutorFor_WithFetchNextIndexCalledRepeatedly_ExecutesAllIterations(t *testing.T) {
+	// arrange
+	N := 20
+	e := parallel.NewExecutor().WithStrategy(parallel.StrategyFetchNextIndex).WithNumGoroutines(3)
+
+	for run := 0; run < 3; run++ {
+		visited := make([]bool, N)
+
+		// act
+		e.For(N, func(i, _ int) {
+			visited[i] = true
+		})
+
+		// assert
+		for i, v := range visited {
+			if !v {
+				t.Errorf("run %d) index %d was not visited\n", run, i)
+			}
+		}
+	}
+}
+
+func Test_ExecutorFor_WithMinItemsPerGoroutine_CapsGoroutines(t *testing.T) {
+	// arrange
+	cases := []struct{ N, grain, expectedNumGR int }{
+		{25, 10, 3}, {3, 1, 3}, {100, 0, 8}, {5, 5, 1}, {1000, 10, 8},
+	}
+
+	for _, c := range cases {
+		e := parallel.NewExecutor().WithNumGoroutines(8).WithMinItemsPerGoroutine(c.grain)
+		visits := make([]int, c.N)
+		counts := make([]int, 8)
+
+		// act
+		e.For(c.N, func(i, grID int) {
+			visits[i]++
+			counts[grID]++
+		})
+
+		// assert
+		numGR := 0
+		for _, count := range counts {
+			if count > 0 {
+				numGR++
+			}
+		}
+		if numGR != c.expectedNumGR {
+			t.Errorf("(N=%d, grain=%d) expected %d goroutines, used %d\n",
+				c.N, c.grain, c.expectedNumGR, numGR)
+		}
+		for i, v := range visits {
+			if v != 1 {
+				t.Errorf("(N=%d, grain=%d) index %d visited %d times\n", c.N, c.grain, i, v)
+				break
+			}
+		}
+	}
+}
+
+func Test_ExecutorFor_WithOneGoroutineWarranted_ExecutesSeriallyInOrder(t *testing.T) {
+	// arrange
+	N := 50
+	e := parallel.NewExecutor().WithNumGoroutines(4).WithMinItemsPerGoroutine(N).
+		WithStrategy(parallel.StrategyFetchNextIndex)
+	var visited []int
+
+	// act
+	e.For(N, func(i, grID int) {
+		if grID != 0 {
+			t.Errorf("expected goroutine 0, received %d\n", grID)
+		}
+		visited = append(visited, i)
+	})
+
+	// assert
+	for i, v := range visited {
+		if i != v {
+			t.Errorf("expected index %d at position %d, received %d\n", i, i, v)
+			break
+		}
+	}
+}
+
+func Test_ExecutorForWithContext_WithSmallN_KeepsGrIDsInRange(t *testing.T) {
+	// arrange
+	N := 3
+	numGR := 8
+	e := parallel.NewExecutor().WithNumGoroutines(numGR)
+	visits := make([]int, N)
+
+	// act
+	err := e.ForWithContext(context.Background(), N, func(_ context.Context, i, grID int) {
+		if grID < 0 || grID >= N {
+			t.Errorf("expected grID in [0, %d), received %d\n", N, grID)
+		}
+		visits[i]++
+	})
+
+	// assert
+	if err != nil {
+		t.Errorf("expected nil error, received %v\n", err)
+	}
+	for i, v := range visits {
+		if v != 1 {
+			t.Errorf("index %d visited %d times\n", i, v)
+		}
+	}
+}
+
+func Test_ExecutorFor_WithZeroIterations_DoesNotCallLoopBody(t *testing.T) {
+	// arrange
+	e := parallel.NewExecutor().WithNumGoroutines(4)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+
+	// act
+	e.For(0, func(_, _ int) {
+		called = true
+	})
+	err := e.ForWithContext(ctx, 0, func(_ context.Context, _, _ int) {
+		called = true
+	})
+
+	// assert
+	if called {
+		t.Errorf("expected loop body to not be called\n")
+	}
+	if err != context.Canceled {
+		t.Errorf("expected %v, received %v\n", context.Canceled, err)
+	}
+}
+
+func Test_Executor_WithZeroValue_ExecutesAllIterations(t *testing.T) {
+	// arrange
+	N := 10
+	var e parallel.Executor
+	visited := make([]bool, N)
+	values := []int{5, 2, 8, 1}
+
+	// act
+	e.For(N, func(i, _ int) {
+		visited[i] = true
+	})
+	filtered := parallel.Filter(&e, values, func(v int) bool {
+		return v > 1
+	})
+	sum := parallel.ReduceOrdered(&e, len(values), 0, func(i int) int {
+		return values[i]
+	}, func(a, b int) int {
+		return a + b
+	})
+
+	// assert
+	if e.NumGoroutines() != 1 {
+		t.Errorf("expected 1 goroutine, actual %d\n", e.NumGoroutines())
+	}
+	for i, v := range visited {
+		if !v {
+			t.Errorf("index %d was not visited\n", i)
+		}
+	}
+	if fmt.Sprint(filtered) != "[5 2 8]" {
+		t.Errorf("expected [5 2 8], actual %v\n", filtered)
+	}
+	if sum != 16 {
+		t.Errorf("expected 16, actual %d\n", sum)
+	}
+}
+
+func benchmarkTinyFor(b *testing.B, e *parallel.Executor) {
+	N := 4
+	x := make([]float64, N)
+
+	for n := 0; n < b.N; n++ {
+		e.For(N, func(i, _ int) {
+			x[i] += float64(i)
+		})
+	}
+}
+
+func BenchmarkTinyForDefaultGrain(b *testing.B) {
+	benchmarkTinyFor(b, parallel.NewExecutor().WithNumGoroutines(8))
+}
+
+func BenchmarkTinyForLargeGrain(b *testing.B) {
+	benchmarkTinyFor(b, parallel.NewExecutor().WithNumGoroutines(8).WithMinItemsPerGoroutine(64))
+}
+
+func BenchmarkTinyForSerial(b *testing.B) {
+	benchmarkTinyFor(b, parallel.NewExecutor().WithNumGoroutines(1))
+}
+
+func Test_ExecutorFor_WithPanicOnCaller_WaitsForOtherGoroutines(t *testing.T) {
+	// arrange
+	numGR := 4
+	e := parallel.NewExecutor().WithNumGoroutines(numGR)
+	var numCompleted int32
+
+	// act
+	recovered := recoverPanic(func() {
+		e.For(numGR, func(_, grID int) {
+			if grID == 0 {
+				panic("iteration failed")
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&numCompleted, 1)
+		})
+	})
+
+	// assert
+	if recovered != "iteration failed" {
+		t.Errorf("expected panic to reach caller, received %v\n", recovered)
+	}
+	if n := atomic.LoadInt32(&numCompleted); n != int32(numGR-1) {
+		t.Errorf("expected %d completed iterations when panic returned, received %d\n",
+			numGR-1, n)
+	}
+}
@@ -0,0 +1,46 @@
+package parallel
+
+// Filter returns the elements of in for which keep returns true, in their original order.
+// The input is split into one contiguous block per goroutine; each goroutine filters its block
+// into a local buffer, and the buffers are then merged into the output at offsets given by a
+// prefix sum of their lengths, so no locking is required.
+// The keep function may be called concurrently from multiple goroutines.
+func Filter[T any](e *Executor, in []T, keep func(v T) bool) []T {
+	buffers := make([][]T, e.NumGoroutines())
+
+	e.forEachBlock(len(buffers), len(in), func(block, start, stop, _ int) {
+		var buffer []T
+		for _, v := range in[start:stop] {
+			if keep(v) {
+				buffer = append(buffer, v)
+			}
+		}
+		buffers[block] = buffer
+	})
+
+	return concatBlocks(e, buffers)
+}
+
+// FlatMap calls f on each element of in and returns all values passed to emit, in input order.
+// Values emitted for in[i] appear before those emitted for in[i+1], and values emitted within a
+// single call to f keep the order in which they were emitted.
+// As with Filter, each goroutine emits into a local buffer for a contiguous block of the input and
+// the buffers are merged using a prefix sum of their lengths.
+// The f function may be called concurrently from multiple goroutines, but emit must only be called
+// from within the call to f that received it.
+func FlatMap[T, R any](e *Executor, in []T, f func(v T, emit func(R))) []R {
+	buffers := make([][]R, e.NumGoroutines())
+
+	e.forEachBlock(len(buffers), len(in), func(block, start, stop, _ int) {
+		var buffer []R
+		emit := func(r R) {
+			buffer = append(buffer, r)
+		}
+		for _, v := range in[start:stop] {
+			f(v, emit)
+		}
+		buffers[block] = buffer
+	})
+
+	return concatBlocks(e, buffers)
+}
@@ -0,0 +1,113 @@
+package parallel_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_Filter_WithVaryingNumGoroutines_PreservesInputOrder(t *testing.T) {
+	// arrange
+	N := 1000
+	input := make([]int, N)
+	for i := range input {
+		input[i] = i
+	}
+	var expectedOutput []int
+	for _, v := range input {
+		if v%3 == 0 {
+			expectedOutput = append(expectedOutput, v)
+		}
+	}
+
+	for _, numGR := range []int{1, 2, 3, 7} {
+		for _, strategy := range []parallel.StrategyType{
+			parallel.StrategyPreassignIndices, parallel.StrategyFetchNextIndex,
+		} {
+			e := parallel.NewExecutor().WithNumGoroutines(numGR).WithStrategy(strategy)
+
+			// act
+			actualOutput := parallel.Filter(e, input, func(v int) bool {
+				return v%3 == 0
+			})
+
+			// assert
+			if !reflect.DeepEqual(expectedOutput, actualOutput) {
+				t.Errorf("%d threads, strategy %d) expected %v, actual %v\n",
+					numGR, strategy, expectedOutput, actualOutput)
+			}
+		}
+	}
+}
+
+func Test_Filter_WithEmptyInput_ReturnsEmptySlice(t *testing.T) {
+	// arrange
+	var input []string
+
+	// act
+	output := parallel.Filter(parallel.NewExecutor(), input, func(string) bool { return true })
+
+	// assert
+	if len(output) != 0 {
+		t.Errorf("expected empty output, actual %v\n", output)
+	}
+}
+
+func Test_FlatMap_WithVaryingNumGoroutines_PreservesInputOrder(t *testing.T) {
+	// arrange
+	counts := []int{3, 0, 1, 4, 0, 2, 5, 1}
+	type item struct{ id, count int }
+	input := make([]item, len(counts))
+	var expectedOutput []string
+	for i, count := range counts {
+		input[i] = item{i, count}
+		for j := 0; j < count; j++ {
+			expectedOutput = append(expectedOutput, fmt.Sprintf("%d.%d", i, j))
+		}
+	}
+
+	for _, numGR := range []int{1, 2, 3, 4, 16} {
+		e := parallel.NewExecutor().WithNumGoroutines(numGR)
+
+		// act
+		actualOutput := parallel.FlatMap(e, input, func(v item, emit func(string)) {
+			for j := 0; j < v.count; j++ {
+				emit(fmt.Sprintf("%d.%d", v.id, j))
+			}
+		})
+
+		// assert
+		if !reflect.DeepEqual(expectedOutput, actualOutput) {
+			t.Errorf("%d threads) expected %v, actual %v\n", numGR, expectedOutput, actualOutput)
+		}
+	}
+}
+
+func ExampleFilter() {
+	x := []int{5, 12, 7, 3, 18, 21, 4, 9}
+
+	// keep values greater than 6, preserving order
+	large := parallel.Filter(parallel.WithNumGoroutines(3), x, func(v int) bool {
+		return v > 6
+	})
+
+	fmt.Println(large)
+	// Output: [12 7 18 21 9]
+}
+
+func ExampleFlatMap() {
+	words := []string{"go", "", "par"}
+
+	// emit each character of each word, preserving order
+	chars := parallel.FlatMap(parallel.WithNumGoroutines(2), words,
+		func(w string, emit func(string)) {
+			for _, c := range w {
+				emit(string(c))
+			}
+		})
+
+	fmt.Println(chars)
+	// Output: [g o p a r]
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+const (
+	defaultAlpha = 14
+	defaultBeta  = 24
+)
+
+// BFSOptions configures a breadth-first search.
+type BFSOptions struct {
+	// Undirected indicates that the adjacency is symmetric, i.e. every edge is stored in both
+	// directions. Bottom-up steps are only used on undirected graphs, since they scan the
+	// neighbours of unvisited vertices in place of their incoming edges.
+	Undirected bool
+
+	// Alpha and Beta are the direction-optimizing thresholds. The search switches from top-down
+	// to bottom-up steps when the edges leaving the frontier exceed 1/Alpha of the edges leaving
+	// unvisited vertices, and back to top-down when the frontier holds fewer than 1/Beta of all
+	// vertices. Values less than 1 default to 14 and 24 respectively.
+	Alpha, Beta int
+}
+
+// BFS performs a level-synchronous parallel breadth-first search of g from source, and returns the
+// distance in edges from source to every vertex. Unreachable vertices have a distance of -1.
+//
+// Top-down steps distribute the frontier among goroutines in chunks of equal total degree, and
+// vertices are claimed through an atomic visited bitset. On undirected graphs, the search
+// switches to bottom-up steps while the frontier is large, where each unvisited vertex looks for
+// a neighbour in the frontier instead of each frontier vertex scanning all of its edges.
+func BFS(e *parallel.Executor, g *CSR, source int, opts BFSOptions) []int {
+	numVertices := g.NumVertices()
+	alpha, beta := opts.Alpha, opts.Beta
+	if alpha < 1 {
+		alpha = defaultAlpha
+	}
+	if beta < 1 {
+		beta = defaultBeta
+	}
+
+	dist := make([]int, numVertices)
+	e.For(numVertices, func(v, _ int) {
+		dist[v] = -1
+	})
+	if source < 0 || source >= numVertices {
+		return dist
+	}
+
+	visited := newBitset(numVertices)
+	visited.insert(source)
+	dist[source] = 0
+
+	numChunks := chunksPerGoroutine * e.NumGoroutines()
+	vertexBounds := vertexRangeChunks(g, numChunks)
+	frontier := []int{source}
+	unexploredEdges := g.NumEdges()
+	bottomUp := false
+
+	for level := 0; len(frontier) > 0; level++ {
+		frontierEdges := 0
+		for _, v := range frontier {
+			frontierEdges += g.Degree(v)
+		}
+
+		if opts.Undirected {
+			if !bottomUp && frontierEdges > unexploredEdges/alpha {
+				bottomUp = true
+			} else if bottomUp && len(frontier) < numVertices/beta {
+				bottomUp = false
+			}
+		}
+		unexploredEdges -= frontierEdges
+
+		next := make([][]int, numChunks)
+		if bottomUp {
+			inFrontier := newBitset(numVertices)
+			e.BlockExecutor().For(len(frontier), func(k, _ int) {
+				inFrontier.insert(frontier[k])
+			})
+
+			forEachChunk(e, vertexBounds, func(chunk, start, stop int) {
+				for v := start; v < stop; v++ {
+					if visited.has(v) {
+						continue
+					}
+					for _, w := range g.Neighbors(v) {
+						if inFrontier.has(w) {
+							visited.insert(v)
+							dist[v] = level + 1
+							next[chunk] = append(next[chunk], v)
+							break
+						}
+					}
+				}
+			})
+		} else {
+			bounds := frontierChunks(g, frontier, numChunks)
+			forEachChunk(e, bounds, func(chunk, start, stop int) {
+				for _, v := range frontier[start:stop] {
+					for _, w := range g.Neighbors(v) {
+						if visited.insert(w) {
+							dist[w] = level + 1
+							next[chunk] = append(next[chunk], w)
+						}
+					}
+				}
+			})
+		}
+
+		frontier = concatChunks(next)
+	}
+
+	return dist
+}
@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"sync/atomic"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// ConnectedComponents labels the connected components of an undirected graph g, whose adjacency
+// must be symmetric. The label of each vertex is the smallest vertex ID in its component, so two
+// vertices are connected if and only if they have the same label.
+//
+// Components are found with a concurrent union-find, where edges are processed in parallel in
+// chunks of equal total degree and roots are linked with atomic compare-and-swap operations.
+func ConnectedComponents(e *parallel.Executor, g *CSR) []int {
+	numVertices := g.NumVertices()
+
+	parent := make([]int64, numVertices)
+	e.For(numVertices, func(v, _ int) {
+		parent[v] = int64(v)
+	})
+
+	bounds := vertexRangeChunks(g, chunksPerGoroutine*e.NumGoroutines())
+	forEachChunk(e, bounds, func(_, start, stop int) {
+		for v := start; v < stop; v++ {
+			for _, w := range g.Neighbors(v) {
+				// each undirected edge is stored twice, so only process one direction
+				if w < v {
+					union(parent, v, w)
+				}
+			}
+		}
+	})
+
+	labels := make([]int, numVertices)
+	e.For(numVertices, func(v, _ int) {
+		labels[v] = int(find(parent, int64(v)))
+	})
+
+	return labels
+}
+
+// find returns the root of x, halving the path to the root along the way.
+// Parent pointers only ever move towards the root, so path halving is safe under concurrent use.
+func find(parent []int64, x int64) int64 {
+	for {
+		p := atomic.LoadInt64(&parent[x])
+		if p == x {
+			return x
+		}
+		gp := atomic.LoadInt64(&parent[p])
+		if gp != p {
+			atomic.CompareAndSwapInt64(&parent[x], p, gp)
+		}
+		x = p
+	}
+}
+
+// union merges the sets containing a and b by linking the larger root beneath the smaller, so
+// the root of each set is always its smallest member.
+func union(parent []int64, a, b int) {
+	x, y := int64(a), int64(b)
+	for {
+		rx, ry := find(parent, x), find(parent, y)
+		if rx == ry {
+			return
+		}
+		if rx < ry {
+			rx, ry = ry, rx
+		}
+		if atomic.CompareAndSwapInt64(&parent[rx], rx, ry) {
+			return
+		}
+		x, y = rx, ry
+	}
+}
@@ -0,0 +1,143 @@
+// Package graph provides parallel traversal of graphs in compressed sparse row (CSR) form, built
+// on the parallel package.
+//
+// Work is distributed among goroutines in chunks of roughly equal total degree rather than equal
+// numbers of vertices, so that a few high-degree vertices do not stall a single goroutine.
+// Loops over vertices use the executor's strategy, while loops over chunks and frontiers use
+// the executor returned by its BlockExecutor() method, so strategies tied to the number of
+// vertices, such as NewOrderStrategy(), may be used.
+package graph
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// chunksPerGoroutine is the number of degree-weighted chunks created per goroutine when
+// distributing vertices, which leaves some slack for the executor's strategy to balance load.
+const chunksPerGoroutine = 4
+
+// CSR is a graph in compressed sparse row form. The neighbours of vertex v are given by
+// Edges[Offsets[v]:Offsets[v+1]], so Offsets has one more element than the number of vertices.
+type CSR struct {
+	Offsets []int
+	Edges   []int
+}
+
+// NumVertices returns the number of vertices in the graph.
+func (g *CSR) NumVertices() int {
+	if len(g.Offsets) == 0 {
+		return 0
+	}
+	return len(g.Offsets) - 1
+}
+
+// NumEdges returns the number of directed edges in the graph. Undirected graphs store each edge
+// in both directions, so each undirected edge is counted twice.
+func (g *CSR) NumEdges() int {
+	if len(g.Offsets) == 0 {
+		return 0
+	}
+	return g.Offsets[len(g.Offsets)-1] - g.Offsets[0]
+}
+
+// Degree returns the number of neighbours of vertex v.
+func (g *CSR) Degree(v int) int {
+	return g.Offsets[v+1] - g.Offsets[v]
+}
+
+// Neighbors returns the neighbours of vertex v.
+func (g *CSR) Neighbors(v int) []int {
+	return g.Edges[g.Offsets[v]:g.Offsets[v+1]]
+}
+
+// vertexRangeChunks splits all vertices into numChunks contiguous ranges of roughly equal total
+// weight, where the weight of a vertex is its degree plus one. The returned bounds have
+// numChunks+1 elements.
+func vertexRangeChunks(g *CSR, numChunks int) []int {
+	numVertices := g.NumVertices()
+	totalWeight := g.NumEdges() + numVertices
+
+	// the cumulative weight of vertices [0, v) is Offsets[v] - Offsets[0] + v
+	bounds := make([]int, numChunks+1)
+	for chunk := 1; chunk <= numChunks; chunk++ {
+		target := totalWeight * chunk / numChunks
+		bounds[chunk] = sort.Search(numVertices, func(v int) bool {
+			return g.Offsets[v]-g.Offsets[0]+v >= target
+		})
+	}
+	bounds[numChunks] = numVertices
+
+	return bounds
+}
+
+// frontierChunks splits a list of vertices into numChunks contiguous ranges of roughly equal
+// total weight, where the weight of a vertex is its degree plus one. The returned bounds have
+// numChunks+1 elements.
+func frontierChunks(g *CSR, frontier []int, numChunks int) []int {
+	// prefix[k] is the total weight of frontier[:k]
+	prefix := make([]int, len(frontier)+1)
+	for k, v := range frontier {
+		prefix[k+1] = prefix[k] + g.Degree(v) + 1
+	}
+
+	bounds := make([]int, numChunks+1)
+	for chunk := 1; chunk <= numChunks; chunk++ {
+		target := prefix[len(frontier)] * chunk / numChunks
+		bounds[chunk] = sort.SearchInts(prefix, target)
+	}
+	bounds[numChunks] = len(frontier)
+
+	return bounds
+}
+
+// forEachChunk executes chunkBody on each chunk given by bounds using the executor's block
+// executor.
+func forEachChunk(e *parallel.Executor, bounds []int, chunkBody func(chunk, start, stop int)) {
+	e.BlockExecutor().For(len(bounds)-1, func(chunk, _ int) {
+		chunkBody(chunk, bounds[chunk], bounds[chunk+1])
+	})
+}
+
+// concatChunks concatenates per-chunk vertex buffers.
+func concatChunks(buffers [][]int) []int {
+	total := 0
+	for _, buffer := range buffers {
+		total += len(buffer)
+	}
+
+	out := make([]int, 0, total)
+	for _, buffer := range buffers {
+		out = append(out, buffer...)
+	}
+	return out
+}
+
+// bitset is a set of vertices which supports concurrent insertion.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+// has reports whether v is in the set. It must not race with insertions of v.
+func (b bitset) has(v int) bool {
+	return atomic.LoadUint64(&b[v/64])&(1<<(uint(v)%64)) != 0
+}
+
+// insert adds v to the set and returns true if v was not already in the set.
+func (b bitset) insert(v int) bool {
+	addr := &b[v/64]
+	mask := uint64(1) << (uint(v) % 64)
+	for {
+		old := atomic.LoadUint64(addr)
+		if old&mask != 0 {
+			return false
+		}
+		if atomic.CompareAndSwapUint64(addr, old, old|mask) {
+			return true
+		}
+	}
+}
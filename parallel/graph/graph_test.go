@@ -0,0 +1,185 @@
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+	"github.com/dgravesa/go-parallel/parallel/graph"
+)
+
+// newUndirectedCSR builds a symmetric CSR graph from a list of undirected edges.
+func newUndirectedCSR(numVertices int, edges [][2]int) *graph.CSR {
+	adjacency := make([][]int, numVertices)
+	for _, edge := range edges {
+		adjacency[edge[0]] = append(adjacency[edge[0]], edge[1])
+		adjacency[edge[1]] = append(adjacency[edge[1]], edge[0])
+	}
+
+	g := &graph.CSR{Offsets: make([]int, numVertices+1)}
+	for v, neighbors := range adjacency {
+		g.Edges = append(g.Edges, neighbors...)
+		g.Offsets[v+1] = len(g.Edges)
+	}
+	return g
+}
+
+// randomUndirectedCSR builds a random graph with a few high-degree hub vertices and several
+// disconnected components.
+func randomUndirectedCSR(numVertices, numEdges int, seed int64) *graph.CSR {
+	r := rand.New(rand.NewSource(seed))
+	var edges [][2]int
+	for k := 0; k < numEdges; k++ {
+		a := r.Intn(numVertices)
+		b := r.Intn(numVertices)
+		if k%5 == 0 {
+			a = r.Intn(3) // hub vertices
+		}
+		// keep vertices in separate components by residue
+		if a%4 == b%4 && a != b {
+			edges = append(edges, [2]int{a, b})
+		}
+	}
+	return newUndirectedCSR(numVertices, edges)
+}
+
+func serialBFS(g *graph.CSR, source int) []int {
+	dist := make([]int, g.NumVertices())
+	for v := range dist {
+		dist[v] = -1
+	}
+	dist[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range g.Neighbors(v) {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return dist
+}
+
+func Test_BFS_WithVaryingOptions_MatchesSerialBFS(t *testing.T) {
+	// arrange
+	g := randomUndirectedCSR(2000, 12000, 1)
+	source := 4
+	expected := serialBFS(g, source)
+
+	optionSets := map[string]graph.BFSOptions{
+		"top-down":          {},
+		"direction-optimal": {Undirected: true},
+		"eager bottom-up":   {Undirected: true, Alpha: 1000, Beta: 1000},
+	}
+
+	for name, opts := range optionSets {
+		for _, numGR := range []int{1, 2, 5} {
+			e := parallel.WithNumGoroutines(numGR).WithStrategy(parallel.StrategyFetchNextIndex)
+
+			// act
+			actual := graph.BFS(e, g, source, opts)
+
+			// assert
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("%s, %d threads) distances do not match serial BFS\n", name, numGR)
+			}
+		}
+	}
+}
+
+func Test_BFSAndConnectedComponents_WithOrderStrategyOverVertices_MatchSerialResults(t *testing.T) {
+	// arrange
+	g := randomUndirectedCSR(1000, 4000, 3)
+	order := rand.New(rand.NewSource(3)).Perm(g.NumVertices())
+	e := parallel.WithNumGoroutines(4).WithCustomStrategy(
+		parallel.NewOrderStrategy(order, parallel.StrategyFetchNextIndex))
+	expected := serialBFS(g, 0)
+
+	// act
+	dist := graph.BFS(e, g, 0, graph.BFSOptions{Undirected: true})
+	labels := graph.ConnectedComponents(e, g)
+
+	// assert
+	if !reflect.DeepEqual(expected, dist) {
+		t.Errorf("distances do not match serial BFS\n")
+	}
+	for v, d := range expected {
+		if (d >= 0) != (labels[v] == labels[0]) {
+			t.Fatalf("vertex %d: distance %d, but label %d and source label %d\n",
+				v, d, labels[v], labels[0])
+		}
+	}
+}
+
+func Test_BFS_WithInvalidSource_ReturnsAllUnreachable(t *testing.T) {
+	// arrange
+	g := newUndirectedCSR(3, [][2]int{{0, 1}, {1, 2}})
+
+	// act
+	dist := graph.BFS(parallel.NewExecutor(), g, 5, graph.BFSOptions{})
+
+	// assert
+	if !reflect.DeepEqual([]int{-1, -1, -1}, dist) {
+		t.Errorf("expected all vertices unreachable, actual %v\n", dist)
+	}
+}
+
+func Test_ConnectedComponents_WithVaryingNumGoroutines_LabelsBySmallestVertex(t *testing.T) {
+	// arrange
+	g := randomUndirectedCSR(3000, 9000, 2)
+	expected := make([]int, g.NumVertices())
+	for v := range expected {
+		expected[v] = -1
+	}
+	for v := range expected {
+		if expected[v] >= 0 {
+			continue
+		}
+		for w, d := range serialBFS(g, v) {
+			if d >= 0 {
+				expected[w] = v
+			}
+		}
+	}
+
+	for _, numGR := range []int{1, 2, 3, 8} {
+		// act
+		actual := graph.ConnectedComponents(parallel.WithNumGoroutines(numGR), g)
+
+		// assert
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%d threads) labels do not match serial labelling\n", numGR)
+		}
+	}
+}
+
+func ExampleBFS() {
+	// 0 - 1 - 2 - 3    4 - 5
+	g := &graph.CSR{
+		Offsets: []int{0, 1, 3, 5, 6, 7, 8},
+		Edges:   []int{1, 0, 2, 1, 3, 2, 5, 4},
+	}
+
+	dist := graph.BFS(parallel.WithNumGoroutines(2), g, 1, graph.BFSOptions{Undirected: true})
+
+	fmt.Println(dist)
+	// Output: [1 0 1 2 -1 -1]
+}
+
+func ExampleConnectedComponents() {
+	// 0 - 1 - 2 - 3    4 - 5
+	g := &graph.CSR{
+		Offsets: []int{0, 1, 3, 5, 6, 7, 8},
+		Edges:   []int{1, 0, 2, 1, 3, 2, 5, 4},
+	}
+
+	labels := graph.ConnectedComponents(parallel.WithNumGoroutines(2), g)
+
+	fmt.Println(labels)
+	// Output: [0 0 0 0 4 4]
+}
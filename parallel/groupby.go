@@ -0,0 +1,91 @@
+package parallel
+
+// GroupBy groups the elements of in by the key returned for each element. Within each group,
+// elements keep their order from in.
+// Each goroutine groups a contiguous block of the input into a local map, and the local maps are
+// merged hierarchically in block order once the loop completes, so no locking is required.
+// The key function may be called concurrently from multiple goroutines.
+func GroupBy[T any, K comparable](e *Executor, in []T, key func(v T) K) map[K][]T {
+	groups := make([]map[K][]T, e.NumGoroutines())
+
+	e.forEachBlock(len(groups), len(in), func(block, start, stop, _ int) {
+		local := make(map[K][]T)
+		for _, v := range in[start:stop] {
+			k := key(v)
+			local[k] = append(local[k], v)
+		}
+		groups[block] = local
+	})
+
+	if len(groups) == 0 {
+		return map[K][]T{}
+	}
+
+	e.mergeTree(len(groups), func(dst, src int) {
+		for k, vs := range groups[src] {
+			groups[dst][k] = append(groups[dst][k], vs...)
+		}
+	})
+
+	return groups[0]
+}
+
+// CountBy counts the elements of in by the key returned for each element.
+// Each goroutine counts into a local map, and the local maps are merged hierarchically once the
+// loop completes. The key function may be called concurrently from multiple goroutines.
+func CountBy[T any, K comparable](e *Executor, in []T, key func(v T) K) map[K]int {
+	counts := make([]map[K]int, e.NumGoroutines())
+	for grID := range counts {
+		counts[grID] = make(map[K]int)
+	}
+
+	e.For(len(in), func(i, grID int) {
+		counts[grID][key(in[i])]++
+	})
+
+	if len(counts) == 0 {
+		return map[K]int{}
+	}
+
+	e.mergeTree(len(counts), func(dst, src int) {
+		for k, count := range counts[src] {
+			counts[dst][k] += count
+		}
+	})
+
+	return counts[0]
+}
+
+// Histogram counts N work items into nbins bins, where bin returns the bin of work index i.
+// Work items whose bin is outside the range [0, nbins) are not counted. If nbins is not positive,
+// an empty histogram is returned without calling bin.
+// Each goroutine counts into local bins, and the local bins are summed hierarchically once the
+// loop completes. The bin function may be called concurrently from multiple goroutines.
+func Histogram(e *Executor, N int, bin func(i int) int, nbins int) []int {
+	if nbins <= 0 {
+		return []int{}
+	}
+
+	counts := make([][]int, e.NumGoroutines())
+	for grID := range counts {
+		counts[grID] = make([]int, nbins)
+	}
+
+	e.For(N, func(i, grID int) {
+		if b := bin(i); b >= 0 && b < nbins {
+			counts[grID][b]++
+		}
+	})
+
+	if len(counts) == 0 {
+		return make([]int, nbins)
+	}
+
+	e.mergeTree(len(counts), func(dst, src int) {
+		for b, count := range counts[src] {
+			counts[dst][b] += count
+		}
+	})
+
+	return counts[0]
+}
@@ -0,0 +1,117 @@
+package parallel_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_GroupBy_WithVaryingNumGoroutines_PreservesOrderWithinGroups(t *testing.T) {
+	// arrange
+	input := randomSortRecords(10000, 37, 4)
+	expected := make(map[int][]sortRecord)
+	for _, r := range input {
+		expected[r.key] = append(expected[r.key], r)
+	}
+
+	for _, numGR := range []int{1, 2, 3, 5, 8} {
+		e := parallel.NewExecutor().WithNumGoroutines(numGR)
+
+		// act
+		actual := parallel.GroupBy(e, input, func(r sortRecord) int {
+			return r.key
+		})
+
+		// assert
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%d threads) groups do not match serial grouping\n", numGR)
+		}
+	}
+}
+
+func Test_CountBy_WithVaryingNumGoroutines_ComputesCorrectResult(t *testing.T) {
+	// arrange
+	input := randomSortRecords(10000, 37, 5)
+	expected := make(map[int]int)
+	for _, r := range input {
+		expected[r.key]++
+	}
+
+	for _, numGR := range []int{1, 2, 3, 5, 8} {
+		e := parallel.NewExecutor().WithNumGoroutines(numGR).
+			WithStrategy(parallel.StrategyFetchNextIndex)
+
+		// act
+		actual := parallel.CountBy(e, input, func(r sortRecord) int {
+			return r.key
+		})
+
+		// assert
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%d threads) expected %v, actual %v\n", numGR, expected, actual)
+		}
+	}
+}
+
+func Test_Histogram_WithOutOfRangeBins_IgnoresOutOfRangeItems(t *testing.T) {
+	// arrange
+	values := []int{-1, 0, 1, 2, 2, 3, 3, 3, 4, 9}
+	expected := []int{1, 1, 2, 3}
+
+	for _, numGR := range []int{1, 2, 3, 4} {
+		// act
+		actual := parallel.Histogram(parallel.WithNumGoroutines(numGR), len(values),
+			func(i int) int {
+				return values[i]
+			}, 4)
+
+		// assert
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%d threads) expected %v, actual %v\n", numGR, expected, actual)
+		}
+	}
+}
+
+func Test_Histogram_WithNonPositiveNumBins_ReturnsEmptyHistogram(t *testing.T) {
+	for _, nbins := range []int{0, -3} {
+		// arrange
+		called := false
+
+		// act
+		actual := parallel.Histogram(parallel.WithNumGoroutines(2), 10, func(i int) int {
+			called = true
+			return i
+		}, nbins)
+
+		// assert
+		if len(actual) != 0 || called {
+			t.Errorf("(%d bins) expected empty histogram without calls, received %v and %v\n",
+				nbins, actual, called)
+		}
+	}
+}
+
+func ExampleGroupBy() {
+	words := []string{"apple", "bear", "avocado", "cat", "banana", "cherry", "apricot"}
+
+	byLetter := parallel.GroupBy(parallel.WithNumGoroutines(3), words, func(w string) byte {
+		return w[0]
+	})
+
+	fmt.Println(byLetter['a'], byLetter['b'], byLetter['c'])
+	// Output: [apple avocado apricot] [bear banana] [cat cherry]
+}
+
+func ExampleHistogram() {
+	ages := []int{3, 17, 25, 31, 38, 42, 47, 59, 64, 71}
+
+	// count ages by decade
+	decades := parallel.Histogram(parallel.WithNumGoroutines(4), len(ages), func(i int) int {
+		return ages[i] / 10
+	}, 8)
+
+	fmt.Println(decades)
+	// Output: [1 1 1 2 2 1 1 1]
+}
@@ -0,0 +1,107 @@
+// Package imaging provides parallel image processing operations, built on the parallel package.
+//
+// Operations distribute rows of the output image among goroutines using the given
+// *parallel.Executor. Fast paths access the pixel buffers of *image.RGBA and *image.Gray
+// directly; other image types are supported through the image.Image and draw.Image interfaces,
+// at a lower speed.
+package imaging
+
+import (
+	"image"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// forRows executes rowBody for each row y of r, with rows distributed among goroutines.
+func forRows(e *parallel.Executor, r image.Rectangle, rowBody func(y int)) {
+	e.For(r.Dy(), func(k, _ int) {
+		rowBody(r.Min.Y + k)
+	})
+}
+
+// floatImage holds premultiplied RGBA samples on a 0-255 scale, four per pixel in row-major
+// order, as intermediate storage for filters.
+type floatImage struct {
+	rect image.Rectangle
+	pix  []float32
+}
+
+// row returns the samples of row y.
+func (f *floatImage) row(y int) []float32 {
+	width := f.rect.Dx()
+	start := 4 * (y - f.rect.Min.Y) * width
+	return f.pix[start : start+4*width]
+}
+
+func newFloatImage(r image.Rectangle) *floatImage {
+	return &floatImage{
+		rect: r,
+		pix:  make([]float32, 4*r.Dx()*r.Dy()),
+	}
+}
+
+// loadFloat converts src into a floatImage.
+func loadFloat(e *parallel.Executor, src image.Image) *floatImage {
+	b := src.Bounds()
+	f := newFloatImage(b)
+
+	forRows(e, b, func(y int) {
+		row := f.row(y)
+
+		switch s := src.(type) {
+		case *image.RGBA:
+			pix := s.Pix[s.PixOffset(b.Min.X, y):]
+			for k := range row {
+				row[k] = float32(pix[k])
+			}
+		case *image.Gray:
+			pix := s.Pix[s.PixOffset(b.Min.X, y):]
+			for x := 0; x < b.Dx(); x++ {
+				v := float32(pix[x])
+				row[4*x], row[4*x+1], row[4*x+2], row[4*x+3] = v, v, v, 255
+			}
+		default:
+			for x := 0; x < b.Dx(); x++ {
+				r, g, bl, a := src.At(b.Min.X+x, y).RGBA()
+				row[4*x] = float32(r) / 257
+				row[4*x+1] = float32(g) / 257
+				row[4*x+2] = float32(bl) / 257
+				row[4*x+3] = float32(a) / 257
+			}
+		}
+	})
+
+	return f
+}
+
+// storeRGBA converts f into an *image.RGBA, rounding and clamping samples such that colour
+// channels do not exceed alpha.
+func storeRGBA(e *parallel.Executor, f *floatImage) *image.RGBA {
+	dst := image.NewRGBA(f.rect)
+
+	forRows(e, f.rect, func(y int) {
+		row := f.row(y)
+		pix := dst.Pix[dst.PixOffset(f.rect.Min.X, y):]
+		for x := 0; x < f.rect.Dx(); x++ {
+			a := clampSample(row[4*x+3], 255)
+			pix[4*x] = clampSample(row[4*x], a)
+			pix[4*x+1] = clampSample(row[4*x+1], a)
+			pix[4*x+2] = clampSample(row[4*x+2], a)
+			pix[4*x+3] = a
+		}
+	})
+
+	return dst
+}
+
+// clampSample rounds v to the nearest integer in [0, max].
+func clampSample(v float32, max uint8) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= float32(max):
+		return max
+	default:
+		return uint8(v + 0.5)
+	}
+}
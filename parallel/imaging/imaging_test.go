@@ -0,0 +1,246 @@
+package imaging_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math/rand"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+	"github.com/dgravesa/go-parallel/parallel/imaging"
+)
+
+func randomRGBA(r image.Rectangle, seed int64) *image.RGBA {
+	img := image.NewRGBA(r)
+	rng := rand.New(rand.NewSource(seed))
+	rng.Read(img.Pix)
+	for k := 0; k < len(img.Pix); k += 4 {
+		img.Pix[k+3] = 255
+	}
+	return img
+}
+
+// wrappedImage hides the concrete type of an image so that generic code paths are used.
+type wrappedImage struct {
+	img draw.Image
+}
+
+func (w wrappedImage) ColorModel() color.Model     { return w.img.ColorModel() }
+func (w wrappedImage) Bounds() image.Rectangle     { return w.img.Bounds() }
+func (w wrappedImage) At(x, y int) color.Color     { return w.img.At(x, y) }
+func (w wrappedImage) Set(x, y int, c color.Color) { w.img.Set(x, y, c) }
+
+func assertImagesEqual(t *testing.T, expected, actual image.Image, prefix string) {
+	if expected.Bounds() != actual.Bounds() {
+		t.Errorf("%sexpected bounds %v, actual %v\n", prefix, expected.Bounds(), actual.Bounds())
+		return
+	}
+	b := expected.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r1, g1, b1, a1 := expected.At(x, y).RGBA()
+			r2, g2, b2, a2 := actual.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				t.Errorf("%spixel (%d, %d): expected %v, actual %v\n",
+					prefix, x, y, expected.At(x, y), actual.At(x, y))
+				return
+			}
+		}
+	}
+}
+
+func Test_MapRGBA_WithInvert_MatchesGenericMap(t *testing.T) {
+	// arrange
+	bounds := image.Rect(3, -2, 40, 25)
+	fast := randomRGBA(bounds, 1)
+	generic := image.NewRGBA(bounds)
+	copy(generic.Pix, fast.Pix)
+	e := parallel.WithNumGoroutines(3)
+
+	// act
+	imaging.MapRGBA(e, fast, func(_, _ int, c color.RGBA) color.RGBA {
+		return color.RGBA{255 - c.R, 255 - c.G, 255 - c.B, c.A}
+	})
+	imaging.Map(e, wrappedImage{generic}, wrappedImage{generic},
+		func(_, _ int, c color.Color) color.Color {
+			rgba := color.RGBAModel.Convert(c).(color.RGBA)
+			return color.RGBA{255 - rgba.R, 255 - rgba.G, 255 - rgba.B, rgba.A}
+		})
+
+	// assert
+	assertImagesEqual(t, generic, fast, "")
+}
+
+func Test_Map_WithFastPaths_MatchesGenericMap(t *testing.T) {
+	// arrange
+	srcBounds := image.Rect(3, -2, 40, 25)
+	dstBounds := image.Rect(0, -5, 45, 30)
+	rgbaSrc := randomRGBA(srcBounds, 4)
+	graySrc := imaging.ToGray(parallel.NewExecutor(), rgbaSrc)
+	e := parallel.WithNumGoroutines(3)
+	// f returns a colour of a different model to exercise the conversion
+	toGray := func(x, _ int, c color.Color) color.Color {
+		g := color.GrayModel.Convert(c).(color.Gray)
+		return color.Gray{Y: g.Y + uint8(x)}
+	}
+
+	cases := map[string]struct {
+		fast, generic draw.Image
+		src           image.Image
+	}{
+		"rgba": {image.NewRGBA(dstBounds), image.NewRGBA(dstBounds), rgbaSrc},
+		"gray": {image.NewGray(dstBounds), image.NewGray(dstBounds), graySrc},
+	}
+
+	for name, c := range cases {
+		// act
+		imaging.Map(e, c.fast, c.src, toGray)
+		imaging.Map(e, wrappedImage{c.generic}, wrappedImage{c.src.(draw.Image)}, toGray)
+
+		// assert
+		assertImagesEqual(t, c.generic, c.fast, name+") ")
+	}
+}
+
+func Test_MapGray_WithThreshold_SetsEachPixel(t *testing.T) {
+	// arrange
+	img := imaging.ToGray(parallel.NewExecutor(), randomRGBA(image.Rect(0, 0, 20, 20), 2))
+
+	// act
+	imaging.MapGray(parallel.WithNumGoroutines(4), img, func(x, y int, c color.Gray) color.Gray {
+		if (x+y)%2 == 0 {
+			return color.Gray{Y: 0}
+		}
+		return color.Gray{Y: 255}
+	})
+
+	// assert
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if expected := uint8(255 * ((x + y) % 2)); img.GrayAt(x, y).Y != expected {
+				t.Fatalf("pixel (%d, %d): expected %d, actual %d\n",
+					x, y, expected, img.GrayAt(x, y).Y)
+			}
+		}
+	}
+}
+
+func Test_ToGray_WithFastAndGenericPaths_MatchesGrayModel(t *testing.T) {
+	// arrange
+	src := randomRGBA(image.Rect(-5, 0, 30, 17), 3)
+	expected := image.NewGray(src.Bounds())
+	for y := src.Rect.Min.Y; y < src.Rect.Max.Y; y++ {
+		for x := src.Rect.Min.X; x < src.Rect.Max.X; x++ {
+			expected.Set(x, y, color.GrayModel.Convert(src.At(x, y)))
+		}
+	}
+	e := parallel.WithNumGoroutines(2)
+
+	// act
+	fast := imaging.ToGray(e, src)
+	generic := imaging.ToGray(e, wrappedImage{src})
+
+	// assert
+	assertImagesEqual(t, expected, fast, "fast) ")
+	assertImagesEqual(t, expected, generic, "generic) ")
+}
+
+func Test_ConvolveSeparable_WithIdentityKernel_ReturnsCopy(t *testing.T) {
+	// arrange
+	src := randomRGBA(image.Rect(0, 0, 33, 21), 4)
+
+	for _, numGR := range []int{1, 3} {
+		// act
+		fast := imaging.ConvolveSeparable(parallel.WithNumGoroutines(numGR), src,
+			[]float64{0, 0, 1, 0, 0})
+		generic := imaging.ConvolveSeparable(parallel.WithNumGoroutines(numGR),
+			wrappedImage{src}, []float64{0, 1, 0})
+
+		// assert
+		assertImagesEqual(t, src, fast, "fast) ")
+		assertImagesEqual(t, src, generic, "generic) ")
+	}
+}
+
+func Test_GaussianBlur_WithUniformImage_LeavesImageUnchanged(t *testing.T) {
+	// arrange
+	src := image.NewGray(image.Rect(0, 0, 25, 25))
+	for k := range src.Pix {
+		src.Pix[k] = 120
+	}
+
+	// act
+	blurred := imaging.GaussianBlur(parallel.WithNumGoroutines(3), src, 2.5)
+
+	// assert
+	assertImagesEqual(t, src, blurred, "")
+}
+
+func Test_Sharpen_WithEdge_IncreasesContrast(t *testing.T) {
+	// arrange
+	src := image.NewGray(image.Rect(0, 0, 20, 5))
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 20; x++ {
+			if x >= 10 {
+				src.SetGray(x, y, color.Gray{Y: 200})
+			} else {
+				src.SetGray(x, y, color.Gray{Y: 50})
+			}
+		}
+	}
+
+	// act
+	sharpened := imaging.Sharpen(parallel.WithNumGoroutines(2), src, 1, 1)
+
+	// assert
+	dark, light := sharpened.RGBAAt(9, 2), sharpened.RGBAAt(10, 2)
+	if dark.R >= 50 || light.R <= 200 {
+		t.Errorf("expected edge contrast to increase, actual %v and %v\n", dark, light)
+	}
+}
+
+func Test_Resize_WithUniformImage_ReturnsUniformImageOfNewSize(t *testing.T) {
+	// arrange
+	src := image.NewRGBA(image.Rect(10, 10, 50, 30))
+	for k := range src.Pix {
+		src.Pix[k] = 90
+	}
+	expected := image.NewRGBA(image.Rect(0, 0, 17, 9))
+	for k := range expected.Pix {
+		expected.Pix[k] = 90
+	}
+
+	// act
+	resized := imaging.Resize(parallel.WithNumGoroutines(3), src, 17, 9)
+
+	// assert
+	assertImagesEqual(t, expected, resized, "")
+}
+
+func Test_Resize_WithDoubledSize_InterpolatesBetweenPixels(t *testing.T) {
+	// arrange
+	src := image.NewGray(image.Rect(0, 0, 2, 1))
+	src.Pix[0], src.Pix[1] = 0, 200
+
+	// act
+	resized := imaging.Resize(parallel.NewExecutor(), src, 4, 1)
+
+	// assert
+	expected := []uint8{0, 50, 150, 200}
+	for x, v := range expected {
+		if resized.RGBAAt(x, 0).R != v {
+			t.Errorf("pixel %d: expected %d, actual %d\n", x, v, resized.RGBAAt(x, 0).R)
+		}
+	}
+}
+
+func BenchmarkGaussianBlur(b *testing.B) {
+	src := randomRGBA(image.Rect(0, 0, 1024, 768), 5)
+	e := parallel.NewExecutor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		imaging.GaussianBlur(e, src, 2)
+	}
+}
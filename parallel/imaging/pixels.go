@@ -0,0 +1,151 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// MapRGBA replaces every pixel of img with the result of calling f on its coordinates and colour.
+// The f function may be called concurrently from multiple goroutines.
+func MapRGBA(e *parallel.Executor, img *image.RGBA, f func(x, y int, c color.RGBA) color.RGBA) {
+	b := img.Bounds()
+
+	forRows(e, b, func(y int) {
+		pix := img.Pix[img.PixOffset(b.Min.X, y):]
+		for x := b.Min.X; x < b.Max.X; x++ {
+			k := 4 * (x - b.Min.X)
+			c := f(x, y, color.RGBA{pix[k], pix[k+1], pix[k+2], pix[k+3]})
+			pix[k], pix[k+1], pix[k+2], pix[k+3] = c.R, c.G, c.B, c.A
+		}
+	})
+}
+
+// MapGray replaces every pixel of img with the result of calling f on its coordinates and
+// intensity. The f function may be called concurrently from multiple goroutines.
+func MapGray(e *parallel.Executor, img *image.Gray, f func(x, y int, c color.Gray) color.Gray) {
+	b := img.Bounds()
+
+	forRows(e, b, func(y int) {
+		pix := img.Pix[img.PixOffset(b.Min.X, y):]
+		for x := b.Min.X; x < b.Max.X; x++ {
+			pix[x-b.Min.X] = f(x, y, color.Gray{Y: pix[x-b.Min.X]}).Y
+		}
+	})
+}
+
+// Map sets each pixel of dst within the bounds of src to the result of calling f on the
+// coordinates and colour of the corresponding pixel of src. The dst and src images may be the
+// same image. If dst and src are both *image.RGBA or both *image.Gray and the bounds of dst
+// contain those of src, their pixel buffers are read and written directly, although colours are
+// still passed to and from f as color.Color values; otherwise the generic At and Set methods are
+// used.
+// The f function may be called concurrently from multiple goroutines.
+func Map(e *parallel.Executor, dst draw.Image, src image.Image,
+	f func(x, y int, c color.Color) color.Color) {
+
+	b := src.Bounds()
+	inDst := b.In(dst.Bounds())
+
+	switch d := dst.(type) {
+	case *image.RGBA:
+		if s, ok := src.(*image.RGBA); ok && inDst {
+			forRows(e, b, func(y int) {
+				srcPix := s.Pix[s.PixOffset(b.Min.X, y):]
+				dstPix := d.Pix[d.PixOffset(b.Min.X, y):]
+				for x := b.Min.X; x < b.Max.X; x++ {
+					k := 4 * (x - b.Min.X)
+					v := f(x, y, color.RGBA{srcPix[k], srcPix[k+1], srcPix[k+2], srcPix[k+3]})
+					c, ok := v.(color.RGBA)
+					if !ok {
+						c = color.RGBAModel.Convert(v).(color.RGBA)
+					}
+					dstPix[k], dstPix[k+1], dstPix[k+2], dstPix[k+3] = c.R, c.G, c.B, c.A
+				}
+			})
+			return
+		}
+	case *image.Gray:
+		if s, ok := src.(*image.Gray); ok && inDst {
+			forRows(e, b, func(y int) {
+				srcPix := s.Pix[s.PixOffset(b.Min.X, y):]
+				dstPix := d.Pix[d.PixOffset(b.Min.X, y):]
+				for x := b.Min.X; x < b.Max.X; x++ {
+					v := f(x, y, color.Gray{Y: srcPix[x-b.Min.X]})
+					c, ok := v.(color.Gray)
+					if !ok {
+						c = color.GrayModel.Convert(v).(color.Gray)
+					}
+					dstPix[x-b.Min.X] = c.Y
+				}
+			})
+			return
+		}
+	}
+
+	forRows(e, b, func(y int) {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			dst.Set(x, y, f(x, y, src.At(x, y)))
+		}
+	})
+}
+
+// ToRGBA returns a copy of src converted to an *image.RGBA with the same bounds.
+func ToRGBA(e *parallel.Executor, src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+
+	forRows(e, b, func(y int) {
+		dstPix := dst.Pix[dst.PixOffset(b.Min.X, y):]
+
+		switch s := src.(type) {
+		case *image.RGBA:
+			copy(dstPix[:4*b.Dx()], s.Pix[s.PixOffset(b.Min.X, y):])
+		case *image.Gray:
+			srcPix := s.Pix[s.PixOffset(b.Min.X, y):]
+			for x := 0; x < b.Dx(); x++ {
+				v := srcPix[x]
+				dstPix[4*x], dstPix[4*x+1], dstPix[4*x+2], dstPix[4*x+3] = v, v, v, 255
+			}
+		default:
+			for x := b.Min.X; x < b.Max.X; x++ {
+				dst.Set(x, y, src.At(x, y))
+			}
+		}
+	})
+
+	return dst
+}
+
+// ToGray returns a copy of src converted to an *image.Gray with the same bounds, using the same
+// luminance weights as color.GrayModel.
+func ToGray(e *parallel.Executor, src image.Image) *image.Gray {
+	b := src.Bounds()
+	dst := image.NewGray(b)
+
+	forRows(e, b, func(y int) {
+		dstPix := dst.Pix[dst.PixOffset(b.Min.X, y):]
+
+		switch s := src.(type) {
+		case *image.Gray:
+			copy(dstPix[:b.Dx()], s.Pix[s.PixOffset(b.Min.X, y):])
+		case *image.RGBA:
+			srcPix := s.Pix[s.PixOffset(b.Min.X, y):]
+			for x := 0; x < b.Dx(); x++ {
+				// equivalent to color.GrayModel on 8-bit channels extended to 16 bits
+				r := uint32(srcPix[4*x]) * 0x101
+				g := uint32(srcPix[4*x+1]) * 0x101
+				bl := uint32(srcPix[4*x+2]) * 0x101
+				dstPix[x] = uint8((19595*r + 38470*g + 7471*bl + 1<<15) >> 24)
+			}
+		default:
+			for x := b.Min.X; x < b.Max.X; x++ {
+				dst.SetGray(x, y, color.GrayModel.Convert(src.At(x, y)).(color.Gray))
+			}
+		}
+	})
+
+	return dst
+}
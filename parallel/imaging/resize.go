@@ -0,0 +1,59 @@
+package imaging
+
+import (
+	"image"
+	"math"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// Resize returns src scaled to width x height pixels using bilinear interpolation. The returned
+// image has its origin at (0, 0), and is empty if width or height is not positive.
+// When shrinking by a large factor, blurring src first with GaussianBlur reduces aliasing.
+func Resize(e *parallel.Executor, src image.Image, width, height int) *image.RGBA {
+	if width <= 0 || height <= 0 {
+		return image.NewRGBA(image.Rectangle{})
+	}
+
+	in := loadFloat(e, src)
+	out := newFloatImage(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := in.rect.Dx(), in.rect.Dy()
+	if srcWidth == 0 || srcHeight == 0 {
+		return storeRGBA(e, out)
+	}
+
+	scaleX := float64(srcWidth) / float64(width)
+	scaleY := float64(srcHeight) / float64(height)
+
+	forRows(e, out.rect, func(y int) {
+		// source row coordinate of this pixel's centre
+		sy := math.Max((float64(y)+0.5)*scaleY-0.5, 0)
+		y0 := minInt(int(sy), srcHeight-1)
+		y1 := minInt(y0+1, srcHeight-1)
+		fy := float32(sy - float64(y0))
+		row0, row1 := in.row(in.rect.Min.Y+y0), in.row(in.rect.Min.Y+y1)
+		dst := out.row(y)
+
+		for x := 0; x < width; x++ {
+			sx := math.Max((float64(x)+0.5)*scaleX-0.5, 0)
+			x0 := minInt(int(sx), srcWidth-1)
+			x1 := minInt(x0+1, srcWidth-1)
+			fx := float32(sx - float64(x0))
+
+			for c := 0; c < 4; c++ {
+				top := row0[4*x0+c] + fx*(row0[4*x1+c]-row0[4*x0+c])
+				bottom := row1[4*x0+c] + fx*(row1[4*x1+c]-row1[4*x0+c])
+				dst[4*x+c] = top + fy*(bottom-top)
+			}
+		}
+	})
+
+	return storeRGBA(e, out)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,98 @@
+package parallel
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxReportedMissing is the number of missing indices listed in index checking diagnostics
+const maxReportedMissing = 10
+
+// indexChecker verifies that the index generators of a loop generate every index in [0, N)
+// exactly once.
+type indexChecker struct {
+	N int
+	// visitors holds 1 + the ID of the goroutine that generated each index, or 0 if not generated
+	visitors []int32
+
+	mu        sync.Mutex
+	violation string
+}
+
+func newIndexChecker(N int) *indexChecker {
+	return &indexChecker{
+		N:        N,
+		visitors: make([]int32, N),
+	}
+}
+
+// generator wraps the index generator of a goroutine. On a violation, the wrapped generator ends
+// the goroutine's work so that the violating index is never passed to the loop body.
+func (c *indexChecker) generator(g IndexGenerator, grID int) IndexGenerator {
+	return &checkedIndexGenerator{
+		checker: c,
+		gen:     g,
+		grID:    grID,
+	}
+}
+
+func (c *indexChecker) fail(format string, args ...interface{}) {
+	c.mu.Lock()
+	if c.violation == "" {
+		c.violation = fmt.Sprintf(format, args...)
+	}
+	c.mu.Unlock()
+}
+
+// verify panics with a diagnostic if an index was out of range or generated more than once, or,
+// unless allowMissing is set, if an index was not generated.
+func (c *indexChecker) verify(allowMissing bool) {
+	if c.violation != "" {
+		panic("parallel: index check failed: " + c.violation)
+	}
+	if allowMissing {
+		return
+	}
+
+	var missing []string
+	numMissing := 0
+	for i := range c.visitors {
+		if c.visitors[i] == 0 {
+			if numMissing < maxReportedMissing {
+				missing = append(missing, fmt.Sprint(i))
+			}
+			numMissing++
+		}
+	}
+	if numMissing > 0 {
+		panic(fmt.Sprintf("parallel: index check failed: %d of %d indices were not generated: %s",
+			numMissing, c.N, strings.Join(missing, ", ")))
+	}
+}
+
+type checkedIndexGenerator struct {
+	checker *indexChecker
+	gen     IndexGenerator
+	grID    int
+}
+
+func (g *checkedIndexGenerator) Next() int {
+	c := g.checker
+	i := g.gen.Next()
+
+	switch {
+	case i >= c.N:
+		return i
+	case i < 0:
+		c.fail("index %d out of range [0, %d) generated by goroutine %d", i, c.N, g.grID)
+		return c.N
+	case !atomic.CompareAndSwapInt32(&c.visitors[i], 0, int32(g.grID+1)):
+		c.fail("index %d generated by goroutine %d was already generated by goroutine %d",
+			i, g.grID, atomic.LoadInt32(&c.visitors[i])-1)
+		return c.N
+	}
+
+	return i
+}
@@ -0,0 +1,193 @@
+package parallel_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// indexListStrategy generates a fixed list of indices for each goroutine ID.
+type indexListStrategy [][]int
+
+func (s indexListStrategy) IndexGenerator(_, grID, N int) parallel.IndexGenerator {
+	var indices []int
+	if grID < len(s) {
+		indices = s[grID]
+	}
+	return &indexListGenerator{indices: indices, N: N}
+}
+
+type indexListGenerator struct {
+	indices []int
+	N       int
+}
+
+func (g *indexListGenerator) Next() int {
+	if len(g.indices) == 0 {
+		return g.N
+	}
+	i := g.indices[0]
+	g.indices = g.indices[1:]
+	return i
+}
+
+// recoverPanic executes f and returns the value it panicked with, or nil.
+func recoverPanic(f func()) (recovered interface{}) {
+	defer func() {
+		recovered = recover()
+	}()
+	f()
+	return nil
+}
+
+func Test_IndexChecking_WithBuiltInStrategies_DoesNotPanic(t *testing.T) {
+	// arrange
+	N := 1000
+	strategies := map[string]parallel.Strategy{
+		"auto":    parallel.NewAutoStrategy(),
+		"shuffle": parallel.NewShuffleStrategy(3, parallel.StrategyFetchNextIndex),
+		"lpt":     parallel.NewLPTStrategyFunc(func(i int) float64 { return float64(i % 13) }),
+	}
+
+	for _, numGR := range []int{1, 3, 8} {
+		executors := map[string]*parallel.Executor{
+			"default": parallel.NewExecutor(),
+			"fetch":   parallel.NewExecutor().WithStrategy(parallel.StrategyFetchNextIndex),
+		}
+		for name, strategy := range strategies {
+			executors[name] = parallel.NewExecutor().WithCustomStrategy(strategy)
+		}
+
+		for name, e := range executors {
+			e = e.WithNumGoroutines(numGR).WithIndexChecking(true)
+
+			// act
+			recovered := recoverPanic(func() {
+				e.For(N, func(_, _ int) {})
+				e.ForWithContext(context.Background(), N, func(_ context.Context, _, _ int) {})
+			})
+
+			// assert
+			if recovered != nil {
+				t.Errorf("(%s, %d threads) unexpected panic: %v\n", name, numGR, recovered)
+			}
+		}
+	}
+}
+
+func Test_IndexChecking_WithInvalidIndices_PanicsWithDiagnostic(t *testing.T) {
+	// arrange
+	cases := map[string]struct {
+		strategy indexListStrategy
+		expected string
+	}{
+		"overlapping":  {indexListStrategy{{0, 1, 2}, {2, 3}}, "already generated by goroutine"},
+		"missing":      {indexListStrategy{{0, 1}, {3}}, "1 of 4 indices were not generated: 2"},
+		"out of range": {indexListStrategy{{0, 1}, {-1, 2, 3}}, "index -1 out of range [0, 4)"},
+	}
+
+	for name, c := range cases {
+		e := parallel.WithCustomStrategy(c.strategy).WithNumGoroutines(2).WithIndexChecking(true)
+		visits := make([]int, 4)
+
+		// act
+		recovered := recoverPanic(func() {
+			e.For(4, func(i, _ int) {
+				visits[i]++
+			})
+		})
+
+		// assert
+		if !strings.Contains(fmt.Sprint(recovered), c.expected) {
+			t.Errorf("(%s) expected panic containing %q, received %v\n", name, c.expected, recovered)
+		}
+		for i, v := range visits {
+			if v > 1 {
+				t.Errorf("(%s) index %d visited %d times\n", name, i, v)
+			}
+		}
+	}
+}
+
+func Test_IndexChecking_WithCanceledContext_AllowsMissingIndices(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	e := parallel.NewExecutor().WithNumGoroutines(2).WithIndexChecking(true)
+
+	// act
+	var err error
+	recovered := recoverPanic(func() {
+		err = e.ForWithContext(ctx, 100, func(_ context.Context, i, _ int) {
+			if i == 10 {
+				cancel()
+			}
+		})
+	})
+
+	// assert
+	if recovered != nil {
+		t.Errorf("unexpected panic: %v\n", recovered)
+	}
+	if err != context.Canceled {
+		t.Errorf("expected %v, received %v\n", context.Canceled, err)
+	}
+}
+
+func Test_Executor_WithInvalidNumGoroutines_ClampsToOne(t *testing.T) {
+	for _, numGR := range []int{0, -3} {
+		// arrange
+		N := 10
+		visits := make([]int, N)
+
+		// act
+		e := parallel.NewExecutor().WithNumGoroutines(numGR)
+		e.For(N, func(i, _ int) {
+			visits[i]++
+		})
+
+		// assert
+		if e.NumGoroutines() != 1 {
+			t.Errorf("(%d) expected 1 goroutine, received %d\n", numGR, e.NumGoroutines())
+		}
+		for i, v := range visits {
+			if v != 1 {
+				t.Errorf("(%d) index %d visited %d times\n", numGR, i, v)
+			}
+		}
+	}
+}
+
+func Test_Executor_WithNonFiniteCPUProportion_HasOneGoroutine(t *testing.T) {
+	for _, p := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), -0.5} {
+		// act
+		e := parallel.NewExecutor().WithCPUProportion(p)
+
+		// assert
+		if e.NumGoroutines() != 1 {
+			t.Errorf("(%v) expected 1 goroutine, received %d\n", p, e.NumGoroutines())
+		}
+	}
+}
+
+func Test_ExecutorFor_WithNegativeN_DoesNotCallLoopBody(t *testing.T) {
+	// arrange
+	e := parallel.NewExecutor().WithNumGoroutines(3).WithIndexChecking(true)
+	called := false
+
+	// act
+	e.For(-5, func(_, _ int) {
+		called = true
+	})
+	err := e.ForWithContext(context.Background(), -5, func(_ context.Context, _, _ int) {
+		called = true
+	})
+
+	// assert
+	if called || err != nil {
+		t.Errorf("expected no calls and nil error, received %v and %v\n", called, err)
+	}
+}
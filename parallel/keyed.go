@@ -0,0 +1,139 @@
+package parallel
+
+import (
+	"sort"
+)
+
+// ForKeyed executes N iterations of a function body, where each iteration has a key and all
+// iterations with the same key are executed by the same goroutine, in ascending index order.
+// Keys are hash-partitioned among the executor's goroutines, so the loop body may keep
+// per-goroutine state, such as a map indexed by grID, without locking:
+//
+//		e.ForKeyed(N, func(i int) uint64 {
+//			return events[i].AccountID
+//		}, func(i, grID int) {
+//			balances[grID][events[i].AccountID] += events[i].Amount
+//		})
+//
+// Iterations are assigned to goroutines by key rather than by the executor's strategy, which is
+// only used to compute the keys. A key is always assigned to the same goroutine ID by executors
+// with the same number of goroutines, so per-goroutine state may be kept across calls, such as
+// for successive batches of events. Only goroutines that are assigned at least one iteration are
+// started.
+// The key function is called once per index, and may be called concurrently from multiple
+// goroutines.
+func (e *Executor) ForKeyed(N int, key func(i int) uint64, loopBody func(i, grID int)) {
+	numGR := e.NumGoroutines()
+	e.forKeyed(N, numGR, key, loopBody, func(keys []uint64) func(k uint64) int {
+		return func(k uint64) int {
+			return int(hashKey(k) % uint64(numGR))
+		}
+	})
+}
+
+// ForKeyedBalanced is the same as ForKeyed(), but keys are assigned to goroutines according to
+// the number of iterations with each key rather than by hash. Keys are assigned in descending
+// order of their number of iterations, each to the goroutine with the fewest iterations assigned
+// so far, which avoids several heavy keys landing on the same goroutine. Counting iterations
+// per key adds some overhead, so ForKeyed() is recommended when keys are roughly uniform.
+// Unlike ForKeyed(), the goroutine ID of a key depends on all the keys of a call, so it is only
+// stable within a single call.
+func (e *Executor) ForKeyedBalanced(N int, key func(i int) uint64, loopBody func(i, grID int)) {
+	numGR := e.NumGoroutines()
+	e.forKeyed(N, numGR, key, loopBody, func(keys []uint64) func(k uint64) int {
+		owners := balancedKeyOwners(e, keys, numGR)
+		return func(k uint64) int {
+			return owners[k]
+		}
+	})
+}
+
+// forKeyed computes the key of each index, assigns indices to numGR goroutines using the owner
+// function created by assign, and then executes each goroutine's indices in ascending order.
+// Goroutines that are assigned no indices are not started.
+func (e *Executor) forKeyed(N, numGR int, key func(i int) uint64, loopBody func(i, grID int),
+	assign func(keys []uint64) func(k uint64) int) {
+
+	if N <= 0 || numGR <= 0 {
+		return
+	}
+
+	keys := make([]uint64, N)
+	e.For(N, func(i, _ int) {
+		keys[i] = key(i)
+	})
+	owner := assign(keys)
+
+	// bucket indices by owner within contiguous blocks, so that concatenating the buckets of each
+	// owner in block order keeps indices in ascending order
+	buckets := make([][][]int, numGR)
+	e.forEachBlock(numGR, N, func(block, start, stop, _ int) {
+		buckets[block] = make([][]int, numGR)
+		for i := start; i < stop; i++ {
+			grID := owner(keys[i])
+			buckets[block][grID] = append(buckets[block][grID], i)
+		}
+	})
+
+	var active []int
+	for grID := 0; grID < numGR; grID++ {
+		for block := range buckets {
+			if len(buckets[block][grID]) > 0 {
+				active = append(active, grID)
+				break
+			}
+		}
+	}
+
+	e.run(len(active), func(k int) {
+		grID := active[k]
+		for block := range buckets {
+			for _, i := range buckets[block][grID] {
+				loopBody(i, grID)
+			}
+		}
+	})
+}
+
+// balancedKeyOwners assigns each distinct key to a goroutine, greedily placing keys with the most
+// iterations first onto the least loaded goroutine.
+func balancedKeyOwners(e *Executor, keys []uint64, numGR int) map[uint64]int {
+	counts := CountBy(e, keys, func(k uint64) uint64 {
+		return k
+	})
+
+	distinct := make([]uint64, 0, len(counts))
+	for k := range counts {
+		distinct = append(distinct, k)
+	}
+	sort.Slice(distinct, func(a, b int) bool {
+		ka, kb := distinct[a], distinct[b]
+		if counts[ka] != counts[kb] {
+			return counts[ka] > counts[kb]
+		}
+		return ka < kb
+	})
+
+	loads := make([]int, numGR)
+	owners := make(map[uint64]int, len(distinct))
+	for _, k := range distinct {
+		leastLoaded := 0
+		for grID, load := range loads {
+			if load < loads[leastLoaded] {
+				leastLoaded = grID
+			}
+		}
+		owners[k] = leastLoaded
+		loads[leastLoaded] += counts[k]
+	}
+
+	return owners
+}
+
+// hashKey mixes the bits of a key so that keys with regular patterns, such as sequential IDs,
+// are spread evenly among goroutines.
+func hashKey(k uint64) uint64 {
+	k = (k ^ (k >> 30)) * 0xbf58476d1ce4e5b9
+	k = (k ^ (k >> 27)) * 0x94d049bb133111eb
+	return k ^ (k >> 31)
+}
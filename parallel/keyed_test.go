@@ -0,0 +1,143 @@
+package parallel_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_ForKeyed_WithVaryingNumGoroutines_ExecutesEachKeyOnOneGoroutineInOrder(t *testing.T) {
+	// arrange
+	N := 2000
+	keyOf := func(i int) uint64 {
+		return uint64((i * 31) % 97)
+	}
+
+	for _, numGR := range []int{1, 2, 3, 8} {
+		for _, balanced := range []bool{false, true} {
+			e := parallel.WithNumGoroutines(numGR)
+			// per-goroutine state, accessed without locking
+			lastIndex := make([]map[uint64]int, numGR)
+			for grID := range lastIndex {
+				lastIndex[grID] = make(map[uint64]int)
+			}
+			keyOwner := make([]int, N)
+			visits := make([]int, N)
+			body := func(i, grID int) {
+				k := keyOf(i)
+				if last, ok := lastIndex[grID][k]; ok && last >= i {
+					t.Errorf("%d threads) index %d executed after index %d\n", numGR, i, last)
+				}
+				lastIndex[grID][k] = i
+				keyOwner[i] = grID
+				visits[i]++
+			}
+
+			// act
+			if balanced {
+				e.ForKeyedBalanced(N, keyOf, body)
+			} else {
+				e.ForKeyed(N, keyOf, body)
+			}
+
+			// assert
+			owners := make(map[uint64]int)
+			for i := 0; i < N; i++ {
+				if visits[i] != 1 {
+					t.Fatalf("%d threads) index %d visited %d times\n", numGR, i, visits[i])
+				}
+				if owner, ok := owners[keyOf(i)]; ok && owner != keyOwner[i] {
+					t.Fatalf("%d threads) key %d executed on goroutines %d and %d\n",
+						numGR, keyOf(i), owner, keyOwner[i])
+				}
+				owners[keyOf(i)] = keyOwner[i]
+			}
+		}
+	}
+}
+
+func Test_ForKeyed_WithVaryingN_AssignsKeysToSameGoroutines(t *testing.T) {
+	// arrange
+	e := parallel.WithNumGoroutines(8)
+	keyOf := func(i int) uint64 {
+		return uint64(i % 5)
+	}
+	owners := make(map[uint64]int)
+
+	for _, N := range []int{3, 100, 1} {
+		// act
+		keyOwner := make([]int, N)
+		e.ForKeyed(N, keyOf, func(i, grID int) {
+			keyOwner[i] = grID
+		})
+
+		// assert
+		for i, grID := range keyOwner {
+			if owner, ok := owners[keyOf(i)]; ok && owner != grID {
+				t.Errorf("N = %d) key %d executed on goroutine %d, previously %d\n",
+					N, keyOf(i), grID, owner)
+			}
+			owners[keyOf(i)] = grID
+		}
+	}
+}
+
+func Test_ForKeyedBalanced_WithHeavyKeys_SpreadsHeavyKeysAcrossGoroutines(t *testing.T) {
+	// arrange
+	// keys 0 to 3 each have 100 iterations, and keys 4 to 19 have one iteration each
+	var keys []uint64
+	for k := uint64(0); k < 4; k++ {
+		for n := 0; n < 100; n++ {
+			keys = append(keys, k)
+		}
+	}
+	for k := uint64(4); k < 20; k++ {
+		keys = append(keys, k)
+	}
+	loads := make([]int, 4)
+
+	// act
+	parallel.WithNumGoroutines(4).ForKeyedBalanced(len(keys), func(i int) uint64 {
+		return keys[i]
+	}, func(_, grID int) {
+		loads[grID]++
+	})
+
+	// assert
+	for grID, load := range loads {
+		if load != 104 {
+			t.Errorf("goroutine %d) expected 104 iterations, actual %d\n", grID, load)
+		}
+	}
+}
+
+func ExampleForKeyed() {
+	type event struct {
+		account uint64
+		amount  int
+	}
+	events := []event{{1, 10}, {2, 5}, {1, -3}, {3, 7}, {2, 2}, {1, 1}}
+
+	// each account is handled by a single goroutine, so per-goroutine maps need no locking
+	numGR := 3
+	balances := make([]map[uint64]int, numGR)
+	for grID := range balances {
+		balances[grID] = make(map[uint64]int)
+	}
+
+	parallel.WithNumGoroutines(numGR).ForKeyed(len(events), func(i int) uint64 {
+		return events[i].account
+	}, func(i, grID int) {
+		balances[grID][events[i].account] += events[i].amount
+	})
+
+	total := make(map[uint64]int)
+	for _, b := range balances {
+		for account, balance := range b {
+			total[account] += balance
+		}
+	}
+	fmt.Println(total)
+	// Output: map[1:8 2:7 3:7]
+}
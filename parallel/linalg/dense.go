@@ -0,0 +1,98 @@
+package linalg
+
+import (
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// MatMul computes the matrix product c = a * b, overwriting c. The storage of c must not overlap
+// that of a or b, since tiles of c are overwritten while a and b are still being read.
+// Tiles of c are computed in parallel, and each tile accumulates over blocks of the inner
+// dimension to keep the working set of a and b in cache.
+func MatMul(e *parallel.Executor, a, b, c *Matrix) {
+	checkDimensions(a.Cols == b.Rows && c.Rows == a.Rows && c.Cols == b.Cols)
+	checkNoOverlap(c.Data, a.Data, b.Data)
+
+	forTiles(e, c.Rows, c.Cols, func(i0, i1, j0, j1 int) {
+		for i := i0; i < i1; i++ {
+			cRow := c.Data[i*c.Cols+j0 : i*c.Cols+j1]
+			for j := range cRow {
+				cRow[j] = 0
+			}
+		}
+
+		for k0 := 0; k0 < a.Cols; k0 += tileSize {
+			k1 := minInt(k0+tileSize, a.Cols)
+			for i := i0; i < i1; i++ {
+				cRow := c.Data[i*c.Cols+j0 : i*c.Cols+j1]
+				for k := k0; k < k1; k++ {
+					aik := a.Data[i*a.Cols+k]
+					bRow := b.Data[k*b.Cols+j0 : k*b.Cols+j1]
+					for j, bkj := range bRow {
+						cRow[j] += aik * bkj
+					}
+				}
+			}
+		}
+	})
+}
+
+// Transpose returns the transpose of a. Tiles are transposed in parallel so that reads and
+// writes both stay within a cache-friendly block.
+func Transpose(e *parallel.Executor, a *Matrix) *Matrix {
+	t := NewMatrix(a.Cols, a.Rows)
+
+	forTiles(e, a.Rows, a.Cols, func(i0, i1, j0, j1 int) {
+		for i := i0; i < i1; i++ {
+			for j := j0; j < j1; j++ {
+				t.Data[j*t.Cols+i] = a.Data[i*a.Cols+j]
+			}
+		}
+	})
+
+	return t
+}
+
+// MatVec computes the matrix-vector product y = a * x, overwriting y. The storage of y must not
+// overlap that of a or x.
+// Rows of a are distributed among goroutines.
+func MatVec(e *parallel.Executor, a *Matrix, x, y []float64) {
+	checkDimensions(len(x) == a.Cols && len(y) == a.Rows)
+	checkNoOverlap(y, a.Data, x)
+
+	e.For(a.Rows, func(i, _ int) {
+		sum := 0.0
+		for j, aij := range a.Data[i*a.Cols : (i+1)*a.Cols] {
+			sum += aij * x[j]
+		}
+		y[i] = sum
+	})
+}
+
+// Axpy computes y = alpha*x + y, overwriting y.
+func Axpy(e *parallel.Executor, alpha float64, x, y []float64) {
+	checkDimensions(len(x) == len(y))
+
+	e.For(len(x), func(i, _ int) {
+		y[i] += alpha * x[i]
+	})
+}
+
+// Dot returns the dot product of x and y.
+// Each goroutine accumulates a partial sum, and the partial sums are added once the loop
+// completes. Since the indices summed by each goroutine depend on the executor, the result may
+// differ in the last bits between executor configurations; parallel.ReproducibleSum may be used
+// where bitwise reproducibility is required.
+func Dot(e *parallel.Executor, x, y []float64) float64 {
+	checkDimensions(len(x) == len(y))
+
+	partialSums := make([]float64, e.NumGoroutines())
+	e.For(len(x), func(i, grID int) {
+		partialSums[grID] += x[i] * y[i]
+	})
+
+	sum := 0.0
+	for _, partialSum := range partialSums {
+		sum += partialSum
+	}
+	return sum
+}
@@ -0,0 +1,93 @@
+// Package linalg provides dense and sparse linear algebra kernels over []float64, built on the
+// parallel package.
+//
+// Every kernel takes a *parallel.Executor, so callers control the number of goroutines and the
+// strategy used, e.g. with WithNumGoroutines() and WithStrategy(). Matrix kernels distribute work
+// as two-dimensional tiles of the output, while reductions such as Dot keep one accumulator per
+// goroutine. Loops over tiles use the executor returned by BlockExecutor(), so strategies tied to
+// the number of rows, such as NewOrderStrategy(), only apply to kernels that loop over rows or
+// elements, such as MatVec and SpMV. Kernels panic if the dimensions of their arguments do not
+// agree, or if an output that is computed from several input elements shares storage with an
+// input.
+package linalg
+
+import (
+	"unsafe"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// tileSize is the height and width of the output tiles distributed among goroutines.
+const tileSize = 64
+
+// Matrix is a dense matrix stored in row-major order, such that the element at row i and column
+// j is Data[i*Cols+j].
+type Matrix struct {
+	Rows, Cols int
+	Data       []float64
+}
+
+// NewMatrix returns a zero-valued matrix with the given dimensions.
+func NewMatrix(rows, cols int) *Matrix {
+	return &Matrix{
+		Rows: rows,
+		Cols: cols,
+		Data: make([]float64, rows*cols),
+	}
+}
+
+// At returns the element at row i and column j.
+func (m *Matrix) At(i, j int) float64 {
+	return m.Data[i*m.Cols+j]
+}
+
+// Set sets the element at row i and column j.
+func (m *Matrix) Set(i, j int, v float64) {
+	m.Data[i*m.Cols+j] = v
+}
+
+// forTiles divides a rows x cols output into square tiles and executes tileBody once per tile,
+// with tiles distributed among goroutines by the executor's block executor.
+func forTiles(e *parallel.Executor, rows, cols int, tileBody func(i0, i1, j0, j1 int)) {
+	numTileRows := (rows + tileSize - 1) / tileSize
+	numTileCols := (cols + tileSize - 1) / tileSize
+
+	e.BlockExecutor().For(numTileRows*numTileCols, func(tile, _ int) {
+		i0 := (tile / numTileCols) * tileSize
+		j0 := (tile % numTileCols) * tileSize
+		tileBody(i0, minInt(i0+tileSize, rows), j0, minInt(j0+tileSize, cols))
+	})
+}
+
+func checkDimensions(ok bool) {
+	if !ok {
+		panic("linalg: dimension mismatch")
+	}
+}
+
+// checkNoOverlap panics if the output slice shares any elements with one of the input slices.
+func checkNoOverlap(out []float64, ins ...[]float64) {
+	for _, in := range ins {
+		if overlaps(out, in) {
+			panic("linalg: output overlaps an input")
+		}
+	}
+}
+
+// overlaps reports whether x and y share any elements.
+func overlaps(x, y []float64) bool {
+	if len(x) == 0 || len(y) == 0 {
+		return false
+	}
+	xStart, yStart := uintptr(unsafe.Pointer(&x[0])), uintptr(unsafe.Pointer(&y[0]))
+	xEnd := xStart + uintptr(len(x))*unsafe.Sizeof(x[0])
+	yEnd := yStart + uintptr(len(y))*unsafe.Sizeof(y[0])
+	return xStart < yEnd && yStart < xEnd
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,283 @@
+package linalg_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+	"github.com/dgravesa/go-parallel/parallel/linalg"
+)
+
+func randomMatrix(rows, cols int, r *rand.Rand) *linalg.Matrix {
+	m := linalg.NewMatrix(rows, cols)
+	for k := range m.Data {
+		m.Data[k] = r.NormFloat64()
+	}
+	return m
+}
+
+func assertFloat64SlicesClose(t *testing.T, expected, actual []float64, prefix string) {
+	if len(expected) != len(actual) {
+		t.Errorf("%sslices do not have same dimension: len(expected) = %d, len(actual) = %d\n",
+			prefix, len(expected), len(actual))
+		return
+	}
+	for k := range expected {
+		if math.Abs(expected[k]-actual[k]) > 1e-9*math.Max(1, math.Abs(expected[k])) {
+			t.Errorf("%sindex %d: expected %v, actual %v\n", prefix, k, expected[k], actual[k])
+			return
+		}
+	}
+}
+
+var testExecutors = map[string]*parallel.Executor{
+	"1 thread":  parallel.WithNumGoroutines(1),
+	"3 threads": parallel.WithNumGoroutines(3),
+	"atomic":    parallel.WithStrategy(parallel.StrategyFetchNextIndex).WithNumGoroutines(4),
+}
+
+func Test_MatMul_WithNonSquareMatrices_MatchesNaiveProduct(t *testing.T) {
+	// arrange
+	r := rand.New(rand.NewSource(1))
+	a, b := randomMatrix(150, 70, r), randomMatrix(70, 130, r)
+	expected := linalg.NewMatrix(150, 130)
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < b.Cols; j++ {
+			sum := 0.0
+			for k := 0; k < a.Cols; k++ {
+				sum += a.At(i, k) * b.At(k, j)
+			}
+			expected.Set(i, j, sum)
+		}
+	}
+
+	for name, e := range testExecutors {
+		c := randomMatrix(150, 130, r) // existing contents must be overwritten
+
+		// act
+		linalg.MatMul(e, a, b, c)
+
+		// assert
+		assertFloat64SlicesClose(t, expected.Data, c.Data, name+") ")
+	}
+}
+
+func Test_Transpose_WithNonSquareMatrix_SwapsRowsAndColumns(t *testing.T) {
+	// arrange
+	a := randomMatrix(100, 67, rand.New(rand.NewSource(2)))
+
+	for name, e := range testExecutors {
+		// act
+		at := linalg.Transpose(e, a)
+
+		// assert
+		if at.Rows != a.Cols || at.Cols != a.Rows {
+			t.Fatalf("%s) expected %dx%d, actual %dx%d\n", name, a.Cols, a.Rows, at.Rows, at.Cols)
+		}
+		for i := 0; i < a.Rows; i++ {
+			for j := 0; j < a.Cols; j++ {
+				if a.At(i, j) != at.At(j, i) {
+					t.Fatalf("%s) element (%d, %d) was not transposed\n", name, i, j)
+				}
+			}
+		}
+	}
+}
+
+func Test_MatVecAndDot_WithRandomInputs_MatchNaiveComputation(t *testing.T) {
+	// arrange
+	r := rand.New(rand.NewSource(3))
+	a := randomMatrix(90, 40, r)
+	x := randomMatrix(1, 40, r).Data
+	expectedY := make([]float64, 90)
+	expectedDot := 0.0
+	for i := range expectedY {
+		for j := range x {
+			expectedY[i] += a.At(i, j) * x[j]
+		}
+		expectedDot += expectedY[i] * expectedY[i]
+	}
+
+	for name, e := range testExecutors {
+		y := make([]float64, 90)
+
+		// act
+		linalg.MatVec(e, a, x, y)
+		dot := linalg.Dot(e, y, y)
+
+		// assert
+		assertFloat64SlicesClose(t, expectedY, y, name+") ")
+		assertFloat64SlicesClose(t, []float64{expectedDot}, []float64{dot}, name+" dot) ")
+	}
+}
+
+func Test_Axpy_WithScalar_UpdatesY(t *testing.T) {
+	// arrange
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{10, 10, 10, 10, 10}
+	expected := []float64{8, 6, 4, 2, 0}
+
+	// act
+	linalg.Axpy(parallel.WithNumGoroutines(2), -2, x, y)
+
+	// assert
+	assertFloat64SlicesClose(t, expected, y, "")
+}
+
+func Test_SpMV_WithSparseMatrix_MatchesDenseProduct(t *testing.T) {
+	// arrange
+	r := rand.New(rand.NewSource(4))
+	dense := linalg.NewMatrix(200, 150)
+	sparse := &linalg.CSRMatrix{Rows: 200, Cols: 150, Offsets: []int{0}}
+	for i := 0; i < dense.Rows; i++ {
+		for j := 0; j < dense.Cols; j++ {
+			if r.Intn(10) == 0 {
+				v := r.NormFloat64()
+				dense.Set(i, j, v)
+				sparse.Indices = append(sparse.Indices, j)
+				sparse.Values = append(sparse.Values, v)
+			}
+		}
+		sparse.Offsets = append(sparse.Offsets, len(sparse.Values))
+	}
+	x := randomMatrix(1, 150, r).Data
+	expected := make([]float64, 200)
+	linalg.MatVec(parallel.WithNumGoroutines(1), dense, x, expected)
+
+	for name, e := range testExecutors {
+		y := make([]float64, 200)
+
+		// act
+		linalg.SpMV(e, sparse, x, y)
+
+		// assert
+		assertFloat64SlicesClose(t, expected, y, name+") ")
+	}
+}
+
+func Test_Kernels_WithOrderStrategyOverRows_ComputeCorrectResults(t *testing.T) {
+	// arrange
+	r := rand.New(rand.NewSource(6))
+	a, b := randomMatrix(150, 150, r), randomMatrix(150, 150, r)
+	x := randomMatrix(1, 150, r).Data
+	expectedC, expectedY := linalg.NewMatrix(150, 150), make([]float64, 150)
+	serial := parallel.WithNumGoroutines(1)
+	linalg.MatMul(serial, a, b, expectedC)
+	linalg.MatVec(serial, a, x, expectedY)
+	expectedT := linalg.Transpose(serial, a)
+
+	e := parallel.WithNumGoroutines(4).WithCustomStrategy(
+		parallel.NewOrderStrategy(r.Perm(a.Rows), parallel.StrategyFetchNextIndex))
+	c, y := linalg.NewMatrix(150, 150), make([]float64, 150)
+
+	// act
+	linalg.MatMul(e, a, b, c)
+	linalg.MatVec(e, a, x, y)
+	transposed := linalg.Transpose(e, a)
+
+	// assert
+	assertFloat64SlicesClose(t, expectedC.Data, c.Data, "MatMul) ")
+	assertFloat64SlicesClose(t, expectedY, y, "MatVec) ")
+	assertFloat64SlicesClose(t, expectedT.Data, transposed.Data, "Transpose) ")
+}
+
+func Test_MatMul_WithMismatchedDimensions_Panics(t *testing.T) {
+	// arrange
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic\n")
+		}
+	}()
+
+	// act
+	linalg.MatMul(parallel.NewExecutor(), linalg.NewMatrix(2, 3), linalg.NewMatrix(2, 3),
+		linalg.NewMatrix(2, 3))
+}
+
+func Test_MatMul_WithOutputAliasingInput_Panics(t *testing.T) {
+	// arrange
+	a := linalg.NewMatrix(3, 3)
+	b := linalg.NewMatrix(3, 3)
+	// c shares the last row of a
+	c := &linalg.Matrix{Rows: 3, Cols: 3, Data: make([]float64, 15)}
+	a.Data = c.Data[6:15]
+
+	for name, out := range map[string]*linalg.Matrix{"same": a, "overlapping": c} {
+		// act
+		recovered := func() (recovered interface{}) {
+			defer func() {
+				recovered = recover()
+			}()
+			linalg.MatMul(parallel.NewExecutor(), a, b, out)
+			return nil
+		}()
+
+		// assert
+		if recovered != "linalg: output overlaps an input" {
+			t.Errorf("(%s) expected overlap panic, received %v\n", name, recovered)
+		}
+	}
+}
+
+func Test_SpMV_WithOutputAliasingInput_Panics(t *testing.T) {
+	// arrange
+	a := &linalg.CSRMatrix{
+		Rows:    3,
+		Cols:    3,
+		Offsets: []int{0, 2, 4, 5},
+		Indices: []int{0, 2, 1, 2, 0},
+		Values:  []float64{1, 1, 1, 1, 1},
+	}
+	x := []float64{1, 2, 3}
+
+	for name, y := range map[string][]float64{"vector": x, "values": a.Values[2:]} {
+		// act
+		recovered := func() (recovered interface{}) {
+			defer func() {
+				recovered = recover()
+			}()
+			linalg.SpMV(parallel.NewExecutor(), a, x, y)
+			return nil
+		}()
+
+		// assert
+		if recovered != "linalg: output overlaps an input" {
+			t.Errorf("(%s) expected overlap panic, received %v\n", name, recovered)
+		}
+	}
+}
+
+func Test_MatMul_WithAdjacentButDistinctStorage_DoesNotPanic(t *testing.T) {
+	// arrange
+	storage := make([]float64, 18)
+	a := &linalg.Matrix{Rows: 3, Cols: 3, Data: storage[0:9]}
+	c := &linalg.Matrix{Rows: 3, Cols: 3, Data: storage[9:18]}
+
+	// act
+	linalg.MatMul(parallel.NewExecutor(), a, linalg.NewMatrix(3, 3), c)
+}
+
+func ExampleMatMul() {
+	a := &linalg.Matrix{Rows: 2, Cols: 3, Data: []float64{1, 2, 3, 4, 5, 6}}
+	b := &linalg.Matrix{Rows: 3, Cols: 2, Data: []float64{7, 8, 9, 10, 11, 12}}
+	c := linalg.NewMatrix(2, 2)
+
+	linalg.MatMul(parallel.WithNumGoroutines(2), a, b, c)
+
+	fmt.Println(c.Data)
+	// Output: [58 64 139 154]
+}
+
+func BenchmarkMatMul(b *testing.B) {
+	r := rand.New(rand.NewSource(5))
+	x, y := randomMatrix(512, 512, r), randomMatrix(512, 512, r)
+	z := linalg.NewMatrix(512, 512)
+	e := parallel.NewExecutor()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		linalg.MatMul(e, x, y, z)
+	}
+}
@@ -0,0 +1,31 @@
+package linalg
+
+import (
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// CSRMatrix is a sparse matrix in compressed sparse row form. The nonzero elements of row i are
+// Values[Offsets[i]:Offsets[i+1]], in the columns given by Indices[Offsets[i]:Offsets[i+1]].
+type CSRMatrix struct {
+	Rows, Cols int
+	Offsets    []int
+	Indices    []int
+	Values     []float64
+}
+
+// SpMV computes the sparse matrix-vector product y = a * x, overwriting y. The storage of y must
+// not overlap that of x or the values of a.
+// Rows of a are distributed among goroutines. For matrices whose rows vary widely in their number
+// of nonzero elements, an executor with StrategyFetchNextIndex may balance load better.
+func SpMV(e *parallel.Executor, a *CSRMatrix, x, y []float64) {
+	checkDimensions(len(x) == a.Cols && len(y) == a.Rows && len(a.Offsets) == a.Rows+1)
+	checkNoOverlap(y, x, a.Values)
+
+	e.For(a.Rows, func(i, _ int) {
+		sum := 0.0
+		for k := a.Offsets[i]; k < a.Offsets[i+1]; k++ {
+			sum += a.Values[k] * x[a.Indices[k]]
+		}
+		y[i] = sum
+	})
+}
@@ -0,0 +1,47 @@
+package parallel
+
+import (
+	"sort"
+)
+
+// NewLPTStrategy returns a strategy where goroutines fetch the next available work index when they
+// are ready, like StrategyFetchNextIndex, but where indices are handed out in descending order of
+// estimated cost rather than ascending index order (longest-processing-time-first ordering).
+// Starting the most expensive iterations first prevents a large iteration that is fetched last
+// from delaying completion of the loop. The estimated cost of index i is given by costs[i], which
+// must have at least N elements for loops of N iterations. Indices of equal cost are handed out in
+// ascending index order. Negative, NaN and infinite costs are treated as zero. Costs are read when
+// a loop starts, and the resulting order is reused by subsequent loops with the same number of
+// iterations, so a new strategy must be created if costs change between such loops.
+// The returned strategy is used with WithCustomStrategy().
+func NewLPTStrategy(costs []float64) Strategy {
+	return NewLPTStrategyFunc(func(i int) float64 {
+		return costs[i]
+	})
+}
+
+// NewLPTStrategyFunc is the same as NewLPTStrategy(), but with the estimated cost of each index
+// given by a function. The cost function is called once per index when a loop starts, and the
+// resulting order is reused by subsequent loops with the same number of iterations.
+func NewLPTStrategyFunc(cost func(i int) float64) Strategy {
+	return newPermutedStrategy(&cachedOrder{compute: func(N int) []int {
+		return descendingCostOrder(cost, N)
+	}}, StrategyFetchNextIndex)
+}
+
+// descendingCostOrder returns the indices [0, N) sorted by descending cost, with ties in
+// ascending index order.
+func descendingCostOrder(cost func(i int) float64, N int) []int {
+	costs := make([]float64, maxInt(N, 0))
+	order := make([]int, len(costs))
+	for i := range order {
+		costs[i] = validCost(cost(i))
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return costs[order[a]] > costs[order[b]]
+	})
+
+	return order
+}
@@ -0,0 +1,108 @@
+package parallel_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_LPTStrategy_WithSingleGoroutine_VisitsIndicesInDescendingCost(t *testing.T) {
+	// arrange
+	costs := []float64{3, 9, 1, 9, 0, 5, -2}
+	expectedOrder := []int{1, 3, 5, 0, 2, 4, 6}
+	e := parallel.WithCustomStrategy(parallel.NewLPTStrategy(costs)).WithNumGoroutines(1)
+
+	for run := 0; run < 2; run++ {
+		var actualOrder []int
+
+		// act
+		e.For(len(costs), func(i, _ int) {
+			actualOrder = append(actualOrder, i)
+		})
+
+		// assert
+		if fmt.Sprint(expectedOrder) != fmt.Sprint(actualOrder) {
+			t.Errorf("run %d) expected %v, actual %v\n", run, expectedOrder, actualOrder)
+		}
+	}
+}
+
+func Test_LPTStrategyFunc_WithVaryingNumGoroutines_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 500
+	strategy := parallel.NewLPTStrategyFunc(func(i int) float64 {
+		return float64(i % 17)
+	})
+
+	for _, numGR := range []int{1, 2, 3, 8} {
+		visits := make([]int32, N)
+		e := parallel.WithCustomStrategy(strategy).WithNumGoroutines(numGR)
+
+		// act
+		e.For(N, func(i, _ int) {
+			visits[i]++
+		})
+
+		// assert
+		for i, v := range visits {
+			if v != 1 {
+				t.Errorf("%d threads) index %d visited %d times\n", numGR, i, v)
+			}
+		}
+	}
+}
+
+// skewedWorkload simulates 60 short iterations followed by 4 long iterations.
+var skewedWorkload = func() []time.Duration {
+	durations := make([]time.Duration, 64)
+	for i := range durations {
+		durations[i] = 100 * time.Microsecond
+		if i >= 60 {
+			durations[i] = 2 * time.Millisecond
+		}
+	}
+	return durations
+}()
+
+func benchmarkSkewedWorkload(b *testing.B, e *parallel.Executor) {
+	for i := 0; i < b.N; i++ {
+		e.For(len(skewedWorkload), func(i, _ int) {
+			time.Sleep(skewedWorkload[i])
+		})
+	}
+}
+
+func BenchmarkSkewedPreassignIndices(b *testing.B) {
+	benchmarkSkewedWorkload(b, parallel.WithStrategy(parallel.StrategyPreassignIndices).
+		WithNumGoroutines(4))
+}
+
+func BenchmarkSkewedFetchNextIndex(b *testing.B) {
+	benchmarkSkewedWorkload(b, parallel.WithStrategy(parallel.StrategyFetchNextIndex).
+		WithNumGoroutines(4))
+}
+
+func BenchmarkSkewedLPT(b *testing.B) {
+	strategy := parallel.NewLPTStrategyFunc(func(i int) float64 {
+		return float64(skewedWorkload[i])
+	})
+	benchmarkSkewedWorkload(b, parallel.WithCustomStrategy(strategy).WithNumGoroutines(4))
+}
+
+func ExampleNewLPTStrategy() {
+	// estimated cost of each request in milliseconds
+	estimates := []float64{10, 10, 10, 10, 10, 10, 80}
+	N := len(estimates)
+
+	// the most expensive request is started first instead of last
+	var startOrder []int
+	strategy := parallel.NewLPTStrategy(estimates)
+	parallel.WithCustomStrategy(strategy).WithNumGoroutines(1).For(N, func(i, _ int) {
+		startOrder = append(startOrder, i)
+	})
+
+	fmt.Println(startOrder)
+	// Output: [6 0 1 2 3 4 5]
+}
@@ -0,0 +1,158 @@
+package parallel
+
+import (
+	"context"
+	"math"
+
+	"github.com/dgravesa/go-parallel/parallel/prng"
+)
+
+// defaultMonteCarloBatchSize is the number of samples per batch used by MonteCarlo when
+// MonteCarloOptions.BatchSize is not set.
+const defaultMonteCarloBatchSize = 10000
+
+// defaultMonteCarloMaxSamples is the maximum number of samples drawn by MonteCarlo when
+// MonteCarloOptions.MaxSamples is not set, so that an estimation always ends.
+const defaultMonteCarloMaxSamples = 100000000
+
+// MonteCarloOptions configures a MonteCarlo estimation.
+type MonteCarloOptions struct {
+	// Seed is the master seed from which each sample's random stream is derived.
+	Seed uint64
+
+	// BatchSize is the number of samples drawn between convergence checks.
+	// If BatchSize is less than 1, a default of 10000 is used.
+	BatchSize int
+
+	// Tolerance is the standard error at or below which the estimate is considered converged.
+	// If Tolerance is not positive, samples are drawn until MaxSamples is reached or the context
+	// is ended.
+	Tolerance float64
+
+	// MinSamples is the minimum number of samples drawn before convergence is checked.
+	// At least 2 samples are always drawn.
+	MinSamples int
+
+	// MaxSamples is the maximum number of samples to draw. If MaxSamples is not positive, a
+	// default of 100000000 is used, so that the zero value of MonteCarloOptions does not run
+	// indefinitely.
+	MaxSamples int
+}
+
+// MonteCarloResult contains the outcome of a MonteCarlo estimation.
+type MonteCarloResult struct {
+	// Estimate is the mean of all samples drawn.
+	Estimate float64
+
+	// StdErr is the standard error of Estimate.
+	StdErr float64
+
+	// NumSamples is the number of samples drawn.
+	NumSamples int
+
+	// Converged is true if StdErr reached the configured tolerance.
+	Converged bool
+}
+
+// MonteCarlo estimates the mean of sampler by drawing batches of samples in parallel until the
+// standard error of the estimate drops to opts.Tolerance, opts.MaxSamples samples have been drawn,
+// or ctx is ended.
+// Sample i is drawn by calling sampler with a random stream derived from opts.Seed and i, so the
+// sampled values do not depend on the executor's configuration. Each goroutine keeps a running
+// mean and variance of the samples it draws, and these are combined between batches to check
+// convergence. Because the combination depends on how samples were distributed, Estimate and
+// StdErr may differ in the last bits between executor configurations.
+//
+// The context is checked between samples in the same way as ForWithContext(). If ctx is ended,
+// the result for the samples drawn so far is returned along with ctx.Err().
+// The sampler function may be called concurrently from multiple goroutines.
+func MonteCarlo(ctx context.Context, e *Executor,
+	sampler func(rng *prng.Stream, i int) float64, opts MonteCarloOptions) (MonteCarloResult, error) {
+
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = defaultMonteCarloBatchSize
+	}
+	minSamples := maxInt(opts.MinSamples, 2)
+	maxSamples := opts.MaxSamples
+	if maxSamples < 1 {
+		maxSamples = defaultMonteCarloMaxSamples
+	}
+
+	accumulators := make([]meanVariance, e.NumGoroutines())
+	var result MonteCarloResult
+
+	for numDrawn := 0; numDrawn < maxSamples; {
+		thisBatchSize := minInt(batchSize, maxSamples-numDrawn)
+
+		firstIndex := numDrawn
+		err := e.ForWithContext(ctx, thisBatchSize, func(_ context.Context, j, grID int) {
+			i := firstIndex + j
+			rng := prng.NewStream(opts.Seed, i)
+			accumulators[grID].add(sampler(&rng, i))
+		})
+		numDrawn += thisBatchSize
+
+		result = monteCarloResult(accumulators)
+		if err != nil {
+			return result, err
+		}
+
+		if opts.Tolerance > 0 && result.NumSamples >= minSamples &&
+			result.StdErr <= opts.Tolerance {
+			result.Converged = true
+			break
+		}
+	}
+
+	return result, ctx.Err()
+}
+
+func monteCarloResult(accumulators []meanVariance) MonteCarloResult {
+	var total meanVariance
+	for _, acc := range accumulators {
+		total.merge(acc)
+	}
+
+	result := MonteCarloResult{
+		Estimate:   total.mean,
+		NumSamples: total.count,
+		StdErr:     math.Inf(1),
+	}
+	if total.count > 1 {
+		variance := total.m2 / float64(total.count-1)
+		result.StdErr = math.Sqrt(variance / float64(total.count))
+	}
+	return result
+}
+
+// meanVariance is a streaming mean and variance accumulator using Welford's algorithm.
+type meanVariance struct {
+	count int
+	mean  float64
+	m2    float64 // sum of squared differences from the mean
+}
+
+func (a *meanVariance) add(x float64) {
+	a.count++
+	delta := x - a.mean
+	a.mean += delta / float64(a.count)
+	a.m2 += delta * (x - a.mean)
+}
+
+// merge combines another accumulator into a using the parallel algorithm of Chan et al.
+func (a *meanVariance) merge(b meanVariance) {
+	if b.count == 0 {
+		return
+	}
+	if a.count == 0 {
+		*a = b
+		return
+	}
+
+	count := a.count + b.count
+	delta := b.mean - a.mean
+	a.mean += delta * float64(b.count) / float64(count)
+	a.m2 += b.m2 + delta*delta*float64(a.count)*float64(b.count)/float64(count)
+	a.count = count
+}
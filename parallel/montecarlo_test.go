@@ -0,0 +1,123 @@
+package parallel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dgravesa/go-parallel/parallel"
+	"github.com/dgravesa/go-parallel/parallel/prng"
+)
+
+func quarterCircleSampler(rng *prng.Stream, _ int) float64 {
+	x, y := rng.Float64(), rng.Float64()
+	if x*x+y*y <= 1 {
+		return 1
+	}
+	return 0
+}
+
+func Test_MonteCarlo_WithTolerance_ConvergesToExpectedValue(t *testing.T) {
+	// arrange
+	expected := math.Pi / 4
+	opts := parallel.MonteCarloOptions{Seed: 7, BatchSize: 5000, Tolerance: 0.002}
+
+	for _, numGR := range []int{1, 3, 4} {
+		// act
+		result, err := parallel.MonteCarlo(context.Background(),
+			parallel.WithNumGoroutines(numGR), quarterCircleSampler, opts)
+
+		// assert
+		if err != nil {
+			t.Errorf("%d threads) unexpected error %v\n", numGR, err)
+		}
+		if !result.Converged || result.StdErr > opts.Tolerance {
+			t.Errorf("%d threads) expected convergence, actual result %+v\n", numGR, result)
+		}
+		if math.Abs(result.Estimate-expected) > 5*result.StdErr {
+			t.Errorf("%d threads) expected approximately %v, actual %v\n",
+				numGR, expected, result.Estimate)
+		}
+	}
+}
+
+func Test_MonteCarlo_WithVaryingExecutors_DrawsSameSamples(t *testing.T) {
+	// arrange
+	opts := parallel.MonteCarloOptions{Seed: 11, BatchSize: 1000, MaxSamples: 4500}
+	expected, _ := parallel.MonteCarlo(context.Background(), parallel.WithNumGoroutines(1),
+		quarterCircleSampler, opts)
+
+	for _, numGR := range []int{2, 3, 8} {
+		e := parallel.WithStrategy(parallel.StrategyFetchNextIndex).WithNumGoroutines(numGR)
+
+		// act
+		actual, _ := parallel.MonteCarlo(context.Background(), e, quarterCircleSampler, opts)
+
+		// assert
+		if expected.NumSamples != actual.NumSamples {
+			t.Errorf("%d threads) expected %d samples, actual %d\n",
+				numGR, expected.NumSamples, actual.NumSamples)
+		}
+		if math.Abs(expected.Estimate-actual.Estimate) > 1e-12 {
+			t.Errorf("%d threads) expected %v, actual %v\n",
+				numGR, expected.Estimate, actual.Estimate)
+		}
+	}
+}
+
+func Test_MonteCarlo_WithMaxSamples_StopsWithoutConverging(t *testing.T) {
+	// arrange
+	opts := parallel.MonteCarloOptions{BatchSize: 300, Tolerance: 1e-9, MaxSamples: 1000}
+
+	// act
+	result, err := parallel.MonteCarlo(context.Background(), parallel.WithNumGoroutines(2),
+		quarterCircleSampler, opts)
+
+	// assert
+	if err != nil {
+		t.Errorf("unexpected error %v\n", err)
+	}
+	if result.NumSamples != opts.MaxSamples || result.Converged {
+		t.Errorf("expected %d unconverged samples, actual result %+v\n", opts.MaxSamples, result)
+	}
+}
+
+func Test_MonteCarlo_WithDeadline_ReturnsPartialResultAndContextError(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	slowSampler := func(rng *prng.Stream, i int) float64 {
+		time.Sleep(100 * time.Microsecond)
+		return rng.Float64()
+	}
+
+	// act
+	result, err := parallel.MonteCarlo(ctx, parallel.WithNumGoroutines(2), slowSampler,
+		parallel.MonteCarloOptions{BatchSize: 100})
+
+	// assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, actual %v\n", err)
+	}
+	if result.NumSamples == 0 || result.Converged {
+		t.Errorf("expected unconverged partial result, actual %+v\n", result)
+	}
+}
+
+func ExampleMonteCarlo() {
+	// estimate pi from the proportion of random points falling within a quarter circle
+	result, _ := parallel.MonteCarlo(context.Background(), parallel.NewExecutor(),
+		func(rng *prng.Stream, _ int) float64 {
+			x, y := rng.Float64(), rng.Float64()
+			if x*x+y*y <= 1 {
+				return 4
+			}
+			return 0
+		}, parallel.MonteCarloOptions{Seed: 1, Tolerance: 0.01})
+
+	fmt.Printf("%.1f %v\n", result.Estimate, result.Converged)
+	// Output: 3.1 true
+}
@@ -0,0 +1,83 @@
+package parallel
+
+import (
+	"math"
+	"runtime"
+)
+
+// Option configures an executor, and is passed to NewExecutor() or Executor.With().
+type Option func(e *Executor)
+
+// NumGoroutines sets the number of goroutines for a parallel executor to use.
+// Values less than 1 are clamped to 1.
+func NumGoroutines(numGoroutines int) Option {
+	return func(e *Executor) {
+		e.numGoroutines = maxInt(numGoroutines, 1)
+	}
+}
+
+// CPUProportion sets the number of goroutines based on a proportion of number of cores,
+// with a minimum of 1. Proportions that are NaN or infinite also result in 1 goroutine.
+func CPUProportion(p float64) Option {
+	return func(e *Executor) {
+		numCPU := runtime.NumCPU()
+		pCPU := p * float64(numCPU)
+		if math.IsNaN(pCPU) || math.IsInf(pCPU, 0) {
+			pCPU = 1.0
+		}
+		e.numGoroutines = int(math.Min(math.Max(pCPU, 1.0), math.MaxInt32))
+	}
+}
+
+// UseStrategy sets the parallel strategy for execution.
+// Different parallel strategies vary on how work items are distributed among goroutines.
+// If either StrategyUseDefaults or an unrecognized value is specified, the
+// defaults will be used for both For() and ForWithContext().
+func UseStrategy(strategyType StrategyType) Option {
+	return func(e *Executor) {
+		switch strategyType {
+		case StrategyPreassignIndices:
+			e.parallelStrategy = newContiguousBlocksStrategy()
+		case StrategyFetchNextIndex:
+			e.parallelStrategy = newAtomicCounterStrategy()
+		case StrategyAuto:
+			e.parallelStrategy = NewAutoStrategy()
+		default:
+			e.parallelStrategy = nil
+		}
+	}
+}
+
+// UseCustomStrategy sets a custom parallel strategy for execution.
+// Defining custom strategies is an advanced feature. Most users should instead specify one of the
+// strategies built into this package using UseStrategy().
+func UseCustomStrategy(customStrategy Strategy) Option {
+	return func(e *Executor) {
+		e.parallelStrategy = customStrategy
+	}
+}
+
+// MinItemsPerGoroutine sets the minimum number of loop iterations, or grain size, that each
+// goroutine is started for. Loops of N iterations use at most ceil(N/grain) goroutines, up to
+// the number of goroutines of the executor, and loops that only warrant one goroutine are executed
+// serially on the calling goroutine. Larger grain sizes reduce the overhead of starting goroutines
+// for loops with few or very short iterations. The default grain size is 1, and values less than
+// 1 are treated as 1.
+func MinItemsPerGoroutine(grain int) Option {
+	return func(e *Executor) {
+		e.minItemsPerGoroutine = grain
+	}
+}
+
+// IndexChecking enables or disables index checking, which verifies that the index generators of
+// the executor's strategy generate every index in [0, N) exactly once. This is intended for
+// testing custom strategies, and slows down loops. With index checking, For() panics with a
+// diagnostic if an index is out of range, is generated more than once, or is not generated at
+// all; indices that are out of range or generated more than once are not passed to the loop
+// body. ForWithContext() performs the same checks, except that indices are allowed to be missing
+// if ctx ended before the loop completed.
+func IndexChecking(check bool) Option {
+	return func(e *Executor) {
+		e.checkIndices = check
+	}
+}
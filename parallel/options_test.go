@@ -0,0 +1,124 @@
+package parallel_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_NewExecutor_WithOptions_AppliesOptions(t *testing.T) {
+	// arrange
+	N := 20
+	visits := make([]int, N)
+
+	// act
+	e := parallel.NewExecutor(parallel.NumGoroutines(3),
+		parallel.UseStrategy(parallel.StrategyFetchNextIndex),
+		parallel.MinItemsPerGoroutine(2), parallel.IndexChecking(true))
+	e.For(N, func(i, _ int) {
+		visits[i]++
+	})
+
+	// assert
+	if e.NumGoroutines() != 3 {
+		t.Errorf("expected 3 goroutines, received %d\n", e.NumGoroutines())
+	}
+	for i, v := range visits {
+		if v != 1 {
+			t.Errorf("index %d visited %d times\n", i, v)
+		}
+	}
+}
+
+func Test_Executor_WithMethods_DoNotModifyReceiver(t *testing.T) {
+	// arrange
+	base := parallel.NewExecutor(parallel.NumGoroutines(4))
+
+	// act
+	derived := base.WithNumGoroutines(2)
+	withOptions := base.With(parallel.CPUProportion(0), parallel.IndexChecking(true))
+	base.WithStrategy(parallel.StrategyFetchNextIndex).WithMinItemsPerGoroutine(100)
+
+	// assert
+	if base.NumGoroutines() != 4 {
+		t.Errorf("expected base to keep 4 goroutines, received %d\n", base.NumGoroutines())
+	}
+	if derived.NumGoroutines() != 2 || withOptions.NumGoroutines() != 1 {
+		t.Errorf("expected derived executors with 2 and 1 goroutines, received %d and %d\n",
+			derived.NumGoroutines(), withOptions.NumGoroutines())
+	}
+
+	// base still uses one goroutine per iteration, with preassigned indices
+	counts := make([]int, 4)
+	base.For(8, func(_, grID int) {
+		counts[grID]++
+	})
+	if fmt.Sprint(counts) != "[2 2 2 2]" {
+		t.Errorf("expected base to use 4 goroutines equally, received %v\n", counts)
+	}
+}
+
+func Test_Executor_Clone_ReturnsIndependentCopy(t *testing.T) {
+	// arrange
+	e := parallel.NewExecutor(parallel.NumGoroutines(5))
+
+	// act
+	clone := e.Clone()
+
+	// assert
+	if clone == e {
+		t.Errorf("expected a new executor instance\n")
+	}
+	if clone.NumGoroutines() != 5 {
+		t.Errorf("expected 5 goroutines, received %d\n", clone.NumGoroutines())
+	}
+}
+
+func Test_Executor_WithConcurrentLoops_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 500
+	numCallers := 6
+	shared := parallel.NewExecutor(parallel.NumGoroutines(3),
+		parallel.UseStrategy(parallel.StrategyFetchNextIndex), parallel.IndexChecking(true))
+	visits := make([][]int, numCallers)
+
+	// act
+	parallel.NewExecutor(parallel.NumGoroutines(numCallers)).For(numCallers,
+		func(caller, _ int) {
+			visits[caller] = make([]int, N)
+			for run := 0; run < 5; run++ {
+				shared.For(N, func(i, _ int) {
+					visits[caller][i]++
+				})
+				shared.ForWithContext(context.Background(), N,
+					func(_ context.Context, i, _ int) {
+						visits[caller][i]++
+					})
+			}
+		})
+
+	// assert
+	for caller := range visits {
+		for i, v := range visits[caller] {
+			if v != 10 {
+				t.Errorf("caller %d, index %d visited %d times\n", caller, i, v)
+				break
+			}
+		}
+	}
+}
+
+func ExampleNewExecutor() {
+	base := parallel.NewExecutor(parallel.NumGoroutines(4),
+		parallel.UseStrategy(parallel.StrategyFetchNextIndex))
+
+	// deriving a variant leaves the shared executor unchanged
+	serial := base.WithNumGoroutines(1)
+
+	fmt.Println(base.NumGoroutines(), serial.NumGoroutines())
+
+	// Output:
+	// 4 1
+}
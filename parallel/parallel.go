@@ -42,6 +42,33 @@ func ForWithContext(ctx context.Context, N int,
 	return NewExecutor().ForWithContext(ctx, N, loopBody)
 }
 
+// ForWithContextSkipped is the same as ForWithContext(), but also returns the indices of the
+// iterations that were skipped because ctx ended before they were started.
+// See Executor.ForWithContextSkipped() for details.
+func ForWithContextSkipped(ctx context.Context, N int,
+	loopBody func(ctx context.Context, i, grID int)) ([]int, error) {
+	return NewExecutor().ForWithContextSkipped(ctx, N, loopBody)
+}
+
+// For2D executes a loop body for every cell of a width x height grid, where the cells are visited
+// in the order of the given curve. With CurveMorton or CurveHilbert, each goroutine works on a
+// compact region of the grid. See Executor.For2D() for details.
+func For2D(width, height int, curve Curve, loopBody func(x, y, grID int)) {
+	NewExecutor().For2D(width, height, curve, loopBody)
+}
+
+// ForKeyed executes N iterations of a function body, where all iterations with the same key are
+// executed by the same goroutine, in ascending index order. See Executor.ForKeyed() for details.
+func ForKeyed(N int, key func(i int) uint64, loopBody func(i, grID int)) {
+	NewExecutor().ForKeyed(N, key, loopBody)
+}
+
+// ForKeyedBalanced is the same as ForKeyed(), but keys are assigned to goroutines according to
+// the number of iterations with each key. See Executor.ForKeyedBalanced() for details.
+func ForKeyedBalanced(N int, key func(i int) uint64, loopBody func(i, grID int)) {
+	NewExecutor().ForKeyedBalanced(N, key, loopBody)
+}
+
 // WithNumGoroutines returns a default executor, but using a specific number of goroutines.
 func WithNumGoroutines(n int) *Executor {
 	return NewExecutor().WithNumGoroutines(n)
@@ -53,6 +80,18 @@ func WithCPUProportion(p float64) *Executor {
 	return NewExecutor().WithCPUProportion(p)
 }
 
+// WithMinItemsPerGoroutine returns a default executor, but with a minimum number of loop
+// iterations per goroutine. See Executor.WithMinItemsPerGoroutine() for details.
+func WithMinItemsPerGoroutine(grain int) *Executor {
+	return NewExecutor().WithMinItemsPerGoroutine(grain)
+}
+
+// WithIndexChecking returns a default executor, but with index checking enabled or disabled. See
+// Executor.WithIndexChecking() for details.
+func WithIndexChecking(check bool) *Executor {
+	return NewExecutor().WithIndexChecking(check)
+}
+
 // WithStrategy returns a default executor, but with a particular parallel strategy for execution.
 // Different parallel strategies vary on how work items are distributed among goroutines.
 // If either StrategyUseDefaults or an unrecognized value is specified, the
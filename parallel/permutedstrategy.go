@@ -0,0 +1,145 @@
+package parallel
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// NewReverseStrategy returns a strategy that visits work indices in descending order, from N-1 to
+// 0. The strategyType determines how positions in this order are distributed among goroutines:
+// StrategyFetchNextIndex hands out positions through a shared counter, while
+// StrategyPreassignIndices and any other value preassign a contiguous block of positions to each
+// goroutine.
+// The returned strategy is used with WithCustomStrategy().
+func NewReverseStrategy(strategyType StrategyType) Strategy {
+	return newPermutedStrategy(reverseOrder{}, strategyType)
+}
+
+// NewShuffleStrategy returns a strategy that visits work indices in a pseudo-random order
+// generated from seed. The same seed and number of iterations always produce the same order, so
+// runs are reproducible. Shuffling spreads expensive iterations evenly among goroutines when
+// the input is sorted by cost, such as a dataset sorted by size.
+// The strategyType determines how positions in this order are distributed among goroutines, as
+// described for NewReverseStrategy().
+func NewShuffleStrategy(seed int64, strategyType StrategyType) Strategy {
+	return newPermutedStrategy(&cachedOrder{compute: func(N int) []int {
+		return rand.New(rand.NewSource(seed)).Perm(N)
+	}}, strategyType)
+}
+
+// NewOrderStrategy returns a strategy that visits work indices in a user-provided order, where
+// order[p] is the work index visited at position p. The order must be a permutation of the
+// indices [0, N) for loops of N iterations; loops of any other number of iterations panic.
+// The strategyType determines how positions in this order are distributed among goroutines, as
+// described for NewReverseStrategy().
+func NewOrderStrategy(order []int, strategyType StrategyType) Strategy {
+	return newPermutedStrategy(userOrder(order), strategyType)
+}
+
+// indexOrder maps positions in a loop's visiting order to work indices.
+type indexOrder interface {
+	// forN returns the work index visited at each position of a loop of N iterations.
+	forN(N int) func(position int) int
+}
+
+// perLoopIndexOrder is implemented by orders that must be recomputed for every loop.
+type perLoopIndexOrder interface {
+	indexOrder
+	newLoop() indexOrder
+}
+
+type reverseOrder struct{}
+
+func (reverseOrder) forN(N int) func(position int) int {
+	return func(position int) int {
+		return N - 1 - position
+	}
+}
+
+type userOrder []int
+
+func (o userOrder) forN(N int) func(position int) int {
+	if N != len(o) {
+		panic("parallel: order length does not match number of loop iterations")
+	}
+	return func(position int) int {
+		return o[position]
+	}
+}
+
+// cachedOrder computes an order for N iterations on first use and reuses it for subsequent loops
+// of the same number of iterations.
+type cachedOrder struct {
+	compute func(N int) []int
+
+	mu     sync.Mutex
+	order  []int
+	orderN int
+}
+
+func (o *cachedOrder) forN(N int) func(position int) int {
+	o.mu.Lock()
+	if o.order == nil || o.orderN != N {
+		o.order = o.compute(N)
+		o.orderN = N
+	}
+	order := o.order
+	o.mu.Unlock()
+
+	return func(position int) int {
+		return order[position]
+	}
+}
+
+// permutedStrategy distributes positions among goroutines using one of the built-in strategies,
+// and maps each position to a work index through an indexOrder.
+type permutedStrategy struct {
+	order        indexOrder
+	strategyType StrategyType
+	positions    Strategy
+}
+
+func newPermutedStrategy(order indexOrder, strategyType StrategyType) *permutedStrategy {
+	s := &permutedStrategy{
+		order:        order,
+		strategyType: strategyType,
+	}
+
+	if strategyType == StrategyFetchNextIndex {
+		s.positions = newAtomicCounterStrategy()
+	} else {
+		s.positions = newContiguousBlocksStrategy()
+	}
+
+	return s
+}
+
+func (s *permutedStrategy) newLoop() Strategy {
+	order := s.order
+	if o, ok := order.(perLoopIndexOrder); ok {
+		order = o.newLoop()
+	}
+	return newPermutedStrategy(order, s.strategyType)
+}
+
+func (s *permutedStrategy) IndexGenerator(numGR, grID, N int) IndexGenerator {
+	return &permutedIndexGenerator{
+		positions: s.positions.IndexGenerator(numGR, grID, N),
+		index:     s.order.forN(N),
+		N:         N,
+	}
+}
+
+type permutedIndexGenerator struct {
+	positions IndexGenerator
+	index     func(position int) int
+	N         int
+}
+
+func (g *permutedIndexGenerator) Next() int {
+	position := g.positions.Next()
+	if position >= g.N {
+		return g.N
+	}
+	return g.index(position)
+}
@@ -0,0 +1,155 @@
+package parallel_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_PermutedStrategies_WithVaryingDistributions_VisitEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 300
+	order := make([]int, N)
+	for p := range order {
+		order[p] = (p * 7) % N // 7 is coprime with 300
+	}
+
+	for _, strategyType := range []parallel.StrategyType{
+		parallel.StrategyPreassignIndices, parallel.StrategyFetchNextIndex,
+	} {
+		strategies := map[string]parallel.Strategy{
+			"reverse": parallel.NewReverseStrategy(strategyType),
+			"shuffle": parallel.NewShuffleStrategy(99, strategyType),
+			"order":   parallel.NewOrderStrategy(order, strategyType),
+		}
+
+		for name, strategy := range strategies {
+			for _, numGR := range []int{1, 3, 8} {
+				e := parallel.WithCustomStrategy(strategy).WithNumGoroutines(numGR)
+
+				for run := 0; run < 2; run++ {
+					visits := make([]int, N)
+
+					// act
+					e.For(N, func(i, _ int) {
+						visits[i]++
+					})
+
+					// assert
+					for i, v := range visits {
+						if v != 1 {
+							t.Errorf("%s, strategy %d, %d threads) index %d visited %d times\n",
+								name, strategyType, numGR, i, v)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_ShuffleStrategy_WithSameSeed_VisitsInSameOrder(t *testing.T) {
+	// arrange
+	N := 20
+	visitOrder := func(seed int64) string {
+		var order []int
+		e := parallel.WithCustomStrategy(parallel.NewShuffleStrategy(seed,
+			parallel.StrategyFetchNextIndex)).WithNumGoroutines(1)
+		e.For(N, func(i, _ int) {
+			order = append(order, i)
+		})
+		return fmt.Sprint(order)
+	}
+
+	// act
+	first, second, other := visitOrder(5), visitOrder(5), visitOrder(6)
+
+	// assert
+	if first != second {
+		t.Errorf("expected same order for same seed, actual %s and %s\n", first, second)
+	}
+	if first == other {
+		t.Errorf("expected different order for different seed, actual %s\n", first)
+	}
+}
+
+func Test_OrderStrategy_WithPreassignIndices_AssignsContiguousPositions(t *testing.T) {
+	// arrange
+	order := []int{5, 4, 3, 2, 1, 0}
+	expectedGrIDs := []int{1, 1, 1, 0, 0, 0}
+	actualGrIDs := make([]int, len(order))
+	strategy := parallel.NewOrderStrategy(order, parallel.StrategyPreassignIndices)
+
+	// act
+	parallel.WithCustomStrategy(strategy).WithNumGoroutines(2).For(len(order), func(i, grID int) {
+		actualGrIDs[i] = grID
+	})
+
+	// assert
+	if fmt.Sprint(expectedGrIDs) != fmt.Sprint(actualGrIDs) {
+		t.Errorf("expected %v, actual %v\n", expectedGrIDs, actualGrIDs)
+	}
+}
+
+func ExampleNewReverseStrategy() {
+	// items are sorted by size, so preassigned blocks would leave the largest items to the last
+	// goroutine; reversing lets goroutines fetching the next index start with the largest items
+	sizes := []int{1, 1, 2, 3, 5, 8, 13, 21}
+	N := len(sizes)
+
+	var visitOrder []int
+	strategy := parallel.NewReverseStrategy(parallel.StrategyFetchNextIndex)
+	parallel.WithCustomStrategy(strategy).WithNumGoroutines(1).For(N, func(i, _ int) {
+		visitOrder = append(visitOrder, sizes[i])
+	})
+
+	fmt.Println(visitOrder)
+	// Output: [21 13 8 5 3 2 1 1]
+}
+
+func Test_OrderStrategy_WithBlockBasedHelpers_ComputesCorrectResults(t *testing.T) {
+	// arrange
+	N := 10000
+	order := make([]int, N)
+	in := make([]int, N)
+	for i := range order {
+		order[i] = N - 1 - i
+		in[i] = (i * 7919) % N
+	}
+	e := parallel.WithCustomStrategy(parallel.NewOrderStrategy(order,
+		parallel.StrategyFetchNextIndex)).WithNumGoroutines(4)
+
+	// act
+	evens := parallel.Filter(e, in, func(v int) bool { return v%2 == 0 })
+	counts := parallel.CountBy(e, in, func(v int) int { return v % 3 })
+	sum := parallel.ReduceOrdered(e, N, 0, func(i int) int { return in[i] }, func(a, b int) int {
+		return a + b
+	})
+	reproducibleSum := parallel.ReproducibleSum(e, N, func(i int) float64 {
+		return float64(in[i])
+	}, parallel.SummationNaive)
+	keyedVisits := make([]int, N)
+	e.ForKeyed(N, func(i int) uint64 { return uint64(in[i] % 5) }, func(i, _ int) {
+		keyedVisits[i]++
+	})
+	sorted := append([]int(nil), in...)
+	parallel.Sort(e, sorted)
+
+	// assert
+	expectedSum := N * (N - 1) / 2
+	if len(evens) != N/2 || counts[0]+counts[1]+counts[2] != N {
+		t.Errorf("expected %d evens and %d counted, received %d and %v\n",
+			N/2, N, len(evens), counts)
+	}
+	if sum != expectedSum || reproducibleSum != float64(expectedSum) {
+		t.Errorf("expected sums of %d, received %d and %v\n", expectedSum, sum, reproducibleSum)
+	}
+	for i := 0; i < N; i++ {
+		if keyedVisits[i] != 1 || sorted[i] != i {
+			t.Errorf("index %d visited %d times, sorted value %d\n", i, keyedVisits[i], sorted[i])
+			break
+		}
+	}
+}
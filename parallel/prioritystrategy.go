@@ -0,0 +1,75 @@
+package parallel
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// NewPriorityStrategy returns a strategy where goroutines fetch the next available work index when
+// they are ready, like StrategyFetchNextIndex, but where indices are handed out in descending
+// order of priority rather than ascending index order. Indices of equal priority are handed out
+// in ascending index order, and NaN priorities are handed out last.
+// The priority function is called once per index at the start of every loop, so priorities may
+// change between loops.
+// When used with ForWithContext(), the most important work is started first, so a timeout skips
+// the least important work; ForWithContextSkipped() reports which indices were skipped.
+// The returned strategy is used with WithCustomStrategy().
+func NewPriorityStrategy(priority func(i int) float64) Strategy {
+	return newPermutedStrategy(perLoopOrder(func(N int) []int {
+		priorities := make([]float64, maxInt(N, 0))
+		for i := range priorities {
+			priorities[i] = priority(i)
+			if math.IsNaN(priorities[i]) {
+				priorities[i] = math.Inf(-1)
+			}
+		}
+		return stableOrder(N, func(i, j int) bool {
+			return priorities[i] > priorities[j]
+		})
+	}), StrategyFetchNextIndex)
+}
+
+// NewDeadlineStrategy returns a strategy where goroutines fetch the next available work index when
+// they are ready, like StrategyFetchNextIndex, but where indices are handed out in order of
+// earliest deadline first (EDF). Indices with equal deadlines are handed out in ascending index
+// order. The deadline function is called once per index at the start of every loop.
+// The returned strategy is used with WithCustomStrategy().
+func NewDeadlineStrategy(deadline func(i int) time.Time) Strategy {
+	return newPermutedStrategy(perLoopOrder(func(N int) []int {
+		deadlines := make([]time.Time, maxInt(N, 0))
+		for i := range deadlines {
+			deadlines[i] = deadline(i)
+		}
+		return stableOrder(N, func(i, j int) bool {
+			return deadlines[i].Before(deadlines[j])
+		})
+	}), StrategyFetchNextIndex)
+}
+
+// stableOrder returns the indices [0, N) sorted such that i precedes j if before(i, j), with ties
+// in ascending index order.
+func stableOrder(N int, before func(i, j int) bool) []int {
+	order := make([]int, maxInt(N, 0))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return before(order[a], order[b])
+	})
+
+	return order
+}
+
+// perLoopOrder computes its order once for every loop, rather than caching it between loops.
+type perLoopOrder func(N int) []int
+
+func (o perLoopOrder) newLoop() indexOrder {
+	return &cachedOrder{compute: o}
+}
+
+func (o perLoopOrder) forN(N int) func(position int) int {
+	// only reached if used outside of an executor loop
+	return o.newLoop().forN(N)
+}
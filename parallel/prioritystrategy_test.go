@@ -0,0 +1,161 @@
+package parallel_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_PriorityStrategy_WithOneGoroutine_VisitsInDescendingPriority(t *testing.T) {
+	// arrange
+	priorities := []float64{2, math.NaN(), 5, -1, 5, 0}
+	expected := []int{2, 4, 0, 5, 3, 1}
+	e := parallel.WithCustomStrategy(parallel.NewPriorityStrategy(func(i int) float64 {
+		return priorities[i]
+	})).WithNumGoroutines(1)
+	var actual []int
+
+	// act
+	e.For(len(priorities), func(i, _ int) {
+		actual = append(actual, i)
+	})
+
+	// assert
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, received %v\n", expected, actual)
+	}
+}
+
+func Test_PriorityStrategy_WithChangedPriorities_RecomputesOrderEachLoop(t *testing.T) {
+	// arrange
+	N := 5
+	reversed := false
+	e := parallel.WithCustomStrategy(parallel.NewPriorityStrategy(func(i int) float64 {
+		if reversed {
+			return float64(-i)
+		}
+		return float64(i)
+	})).WithNumGoroutines(1)
+	visitOrder := func() string {
+		var order []int
+		e.For(N, func(i, _ int) {
+			order = append(order, i)
+		})
+		return fmt.Sprint(order)
+	}
+
+	// act
+	first := visitOrder()
+	reversed = true
+	second := visitOrder()
+
+	// assert
+	if first != "[4 3 2 1 0]" || second != "[0 1 2 3 4]" {
+		t.Errorf("received orders %s and %s\n", first, second)
+	}
+}
+
+func Test_DeadlineStrategy_WithOneGoroutine_VisitsEarliestDeadlineFirst(t *testing.T) {
+	// arrange
+	now := time.Now()
+	deadlines := []time.Duration{3, 1, 4, 1, 5}
+	expected := []int{1, 3, 0, 2, 4}
+	e := parallel.WithCustomStrategy(parallel.NewDeadlineStrategy(func(i int) time.Time {
+		return now.Add(deadlines[i] * time.Second)
+	})).WithNumGoroutines(1)
+	var actual []int
+
+	// act
+	e.For(len(deadlines), func(i, _ int) {
+		actual = append(actual, i)
+	})
+
+	// assert
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, received %v\n", expected, actual)
+	}
+}
+
+func Test_PriorityStrategy_WithManyGoroutines_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 500
+	e := parallel.WithCustomStrategy(parallel.NewPriorityStrategy(func(i int) float64 {
+		return float64(i % 7)
+	})).WithNumGoroutines(8)
+	visits := make([]int, N)
+
+	// act
+	e.For(N, func(i, _ int) {
+		visits[i]++
+	})
+
+	// assert
+	for i, v := range visits {
+		if v != 1 {
+			t.Errorf("index %d visited %d times\n", i, v)
+			break
+		}
+	}
+}
+
+func Test_ForWithContextSkipped_WithCompletedLoop_ReturnsNoSkipped(t *testing.T) {
+	// arrange
+	N := 100
+
+	// act
+	skipped, err := parallel.ForWithContextSkipped(context.Background(), N,
+		func(_ context.Context, _, _ int) {})
+
+	// assert
+	if err != nil || len(skipped) != 0 {
+		t.Errorf("expected no error and no skipped, received %v and %v\n", err, skipped)
+	}
+}
+
+func Test_ForWithContextSkipped_WithCancelAndPriority_SkipsLowestPriority(t *testing.T) {
+	// arrange
+	N := 10
+	ctx, cancel := context.WithCancel(context.Background())
+	e := parallel.WithCustomStrategy(parallel.NewPriorityStrategy(func(i int) float64 {
+		return float64(i)
+	})).WithNumGoroutines(1)
+
+	// act
+	skipped, err := e.ForWithContextSkipped(ctx, N, func(_ context.Context, i, _ int) {
+		if i == 6 {
+			cancel()
+		}
+	})
+
+	// assert
+	if err != context.Canceled {
+		t.Errorf("expected %v, received %v\n", context.Canceled, err)
+	}
+	if fmt.Sprint(skipped) != "[0 1 2 3 4 5]" {
+		t.Errorf("expected [0 1 2 3 4 5] skipped, received %v\n", skipped)
+	}
+}
+
+func ExampleNewDeadlineStrategy() {
+	start := time.Now()
+	deadlines := []time.Time{
+		start.Add(3 * time.Second), start.Add(time.Second), start.Add(2 * time.Second),
+	}
+
+	e := parallel.WithCustomStrategy(parallel.NewDeadlineStrategy(func(i int) time.Time {
+		return deadlines[i]
+	})).WithNumGoroutines(1)
+
+	e.For(len(deadlines), func(i, _ int) {
+		fmt.Println("job", i)
+	})
+
+	// Output:
+	// job 1
+	// job 2
+	// job 0
+}
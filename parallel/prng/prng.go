@@ -0,0 +1,113 @@
+// Package prng provides counter-based pseudo-random number streams for use in parallel loops.
+//
+// Seeding one rand.Rand per goroutine makes the random values used by a loop iteration depend on
+// the number of goroutines and on which goroutine executes the iteration. Instead, a Stream is
+// derived from a master seed and the loop index, so that each iteration draws the same values
+// regardless of how iterations are distributed among goroutines:
+//
+//	parallel.For(N, func(i, _ int) {
+//		rng := prng.NewStream(seed, i)
+//		samples[i] = rng.NormFloat64()
+//	})
+//
+// Streams are generated by applying a SplitMix64 mixing function to a per-stream key plus a
+// counter, so creating a stream is cheap and requires no allocation. Streams for different
+// indices are statistically independent for practical purposes, but this package is not
+// suitable for cryptographic use.
+package prng
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	// golden ratio increment used by SplitMix64
+	golden = 0x9e3779b97f4a7c15
+	// increment used to separate stream keys from outputs of the same seed
+	indexIncrement = 0xd1b54a32d192ed03
+)
+
+// Stream is a deterministic pseudo-random number stream for one loop index.
+// A Stream is not safe for concurrent use; each loop iteration should create its own.
+// *Stream implements math/rand.Source64, so rand.New(stream) may be used where the methods of
+// rand.Rand are needed.
+type Stream struct {
+	key     uint64
+	counter uint64
+}
+
+// NewStream returns the stream for loop index i under a master seed.
+// The same seed and index always produce the same sequence of values.
+func NewStream(seed uint64, i int) Stream {
+	return Stream{
+		key: mix(seed ^ mix(uint64(i)*indexIncrement+golden)),
+	}
+}
+
+// mix is the SplitMix64 output function.
+func mix(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Uint64 returns a pseudo-random 64-bit value.
+func (s *Stream) Uint64() uint64 {
+	s.counter++
+	return mix(s.key + s.counter*golden)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (s *Stream) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed resets the stream to the stream for loop index 0 under the given seed.
+// It is provided to implement math/rand.Source.
+func (s *Stream) Seed(seed int64) {
+	*s = NewStream(uint64(seed), 0)
+}
+
+// Float64 returns a pseudo-random number in the half-open interval [0.0, 1.0).
+func (s *Stream) Float64() float64 {
+	return float64(s.Uint64()>>11) / (1 << 53)
+}
+
+// Intn returns a pseudo-random number in the half-open interval [0, n).
+// It panics if n <= 0.
+func (s *Stream) Intn(n int) int {
+	if n <= 0 {
+		panic("prng: invalid argument to Intn")
+	}
+
+	// Lemire's multiply-shift method with rejection to remove bias
+	bound := uint64(n)
+	hi, lo := bits.Mul64(s.Uint64(), bound)
+	if lo < bound {
+		threshold := -bound % bound
+		for lo < threshold {
+			hi, lo = bits.Mul64(s.Uint64(), bound)
+		}
+	}
+	return int(hi)
+}
+
+// NormFloat64 returns a normally distributed pseudo-random number with mean 0 and standard
+// deviation 1.
+func (s *Stream) NormFloat64() float64 {
+	// Marsaglia polar method, discarding the second value to keep streams stateless
+	for {
+		u := 2*s.Float64() - 1
+		v := 2*s.Float64() - 1
+		r := u*u + v*v
+		if r > 0 && r < 1 {
+			return u * math.Sqrt(-2*math.Log(r)/r)
+		}
+	}
+}
+
+// ExpFloat64 returns an exponentially distributed pseudo-random number with rate 1.
+func (s *Stream) ExpFloat64() float64 {
+	return -math.Log1p(-s.Float64())
+}
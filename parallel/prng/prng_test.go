@@ -0,0 +1,161 @@
+package prng_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+	"github.com/dgravesa/go-parallel/parallel/prng"
+)
+
+func Test_NewStream_WithSameSeedAndIndex_ProducesSameSequence(t *testing.T) {
+	// arrange
+	s1 := prng.NewStream(42, 7)
+	s2 := prng.NewStream(42, 7)
+
+	for k := 0; k < 100; k++ {
+		// act
+		v1, v2 := s1.Uint64(), s2.Uint64()
+
+		// assert
+		if v1 != v2 {
+			t.Fatalf("value %d) expected %d, actual %d\n", k, v1, v2)
+		}
+	}
+}
+
+func Test_NewStream_WithDifferentIndicesOrSeeds_ProducesDifferentSequences(t *testing.T) {
+	// arrange
+	first := make(map[uint64]string)
+	for seed := uint64(0); seed < 4; seed++ {
+		for i := 0; i < 1000; i++ {
+			s := prng.NewStream(seed, i)
+
+			// act
+			v := s.Uint64()
+
+			// assert
+			name := fmt.Sprintf("seed %d, index %d", seed, i)
+			if other, ok := first[v]; ok {
+				t.Errorf("%s and %s produced the same first value\n", name, other)
+			}
+			first[v] = name
+		}
+	}
+}
+
+func Test_Stream_Float64AndIntn_ReturnValuesInRange(t *testing.T) {
+	// arrange
+	s := prng.NewStream(1, 0)
+	n := 7
+	counts := make([]int, n)
+	sum := 0.0
+	numSamples := 70000
+
+	for k := 0; k < numSamples; k++ {
+		// act
+		f := s.Float64()
+		m := s.Intn(n)
+
+		// assert
+		if f < 0 || f >= 1 {
+			t.Fatalf("Float64 returned %v, outside [0, 1)\n", f)
+		}
+		if m < 0 || m >= n {
+			t.Fatalf("Intn(%d) returned %d\n", n, m)
+		}
+		sum += f
+		counts[m]++
+	}
+
+	if mean := sum / float64(numSamples); math.Abs(mean-0.5) > 0.01 {
+		t.Errorf("Float64 mean is %v, expected approximately 0.5\n", mean)
+	}
+	for m, count := range counts {
+		if math.Abs(float64(count)-float64(numSamples/n)) > 0.05*float64(numSamples/n) {
+			t.Errorf("Intn value %d occurred %d times, expected approximately %d\n",
+				m, count, numSamples/n)
+		}
+	}
+}
+
+func Test_NewStream_InParallelLoops_ProducesIdenticalOutputsForAnyExecutor(t *testing.T) {
+	// arrange
+	N := 1000
+	seed := uint64(2021)
+	expected := make([]float64, N)
+	for i := range expected {
+		rng := prng.NewStream(seed, i)
+		expected[i] = rng.NormFloat64() + rng.Float64()
+	}
+
+	executors := map[string]*parallel.Executor{
+		"default":   parallel.NewExecutor(),
+		"3 threads": parallel.WithNumGoroutines(3),
+		"atomic": parallel.WithStrategy(parallel.StrategyFetchNextIndex).
+			WithNumGoroutines(5),
+	}
+
+	for name, e := range executors {
+		actual := make([]float64, N)
+
+		// act
+		e.For(N, func(i, _ int) {
+			rng := prng.NewStream(seed, i)
+			actual[i] = rng.NormFloat64() + rng.Float64()
+		})
+		contextual := make([]float64, N)
+		_ = e.ForWithContext(context.Background(), N, func(_ context.Context, i, _ int) {
+			rng := prng.NewStream(seed, i)
+			contextual[i] = rng.NormFloat64() + rng.Float64()
+		})
+
+		// assert
+		for i := range expected {
+			if expected[i] != actual[i] || expected[i] != contextual[i] {
+				t.Errorf("%s) index %d: expected %v, actual %v and %v\n",
+					name, i, expected[i], actual[i], contextual[i])
+				break
+			}
+		}
+	}
+}
+
+func Test_Stream_AsRandSource_IsDeterministic(t *testing.T) {
+	// arrange
+	s1 := prng.NewStream(9, 3)
+	s2 := prng.NewStream(9, 3)
+	r1, r2 := rand.New(&s1), rand.New(&s2)
+
+	// act
+	p1, p2 := r1.Perm(10), r2.Perm(10)
+
+	// assert
+	if fmt.Sprint(p1) != fmt.Sprint(p2) {
+		t.Errorf("expected %v, actual %v\n", p1, p2)
+	}
+}
+
+func ExampleNewStream() {
+	N := 8
+	seed := uint64(1234)
+	dice := make([]int, N)
+
+	// each iteration draws from its own stream, so results do not depend on the executor
+	parallel.WithNumGoroutines(3).For(N, func(i, _ int) {
+		rng := prng.NewStream(seed, i)
+		dice[i] = rng.Intn(6) + 1
+	})
+
+	again := make([]int, N)
+	parallel.WithStrategy(parallel.StrategyFetchNextIndex).For(N, func(i, _ int) {
+		rng := prng.NewStream(seed, i)
+		again[i] = rng.Intn(6) + 1
+	})
+
+	fmt.Println(fmt.Sprint(dice) == fmt.Sprint(again))
+	// Output: true
+}
@@ -0,0 +1,36 @@
+package parallel
+
+// ReduceOrdered computes value(0), value(1), ..., value(N-1) and combines them in index order,
+// such that the result is equivalent to the serial loop:
+//
+//		acc := identity
+//		for i := 0; i < N; i++ {
+//			acc = combine(acc, value(i))
+//		}
+//
+// The combine function must be associative, but it need not be commutative; for example, it may
+// concatenate strings or compose transformations.
+// Work indices are split into one contiguous block per goroutine, and each block is reduced
+// serially in ascending index order, starting from identity. The block results are then combined
+// from left to right. Blocks are distributed among goroutines with the default strategies rather
+// than the executor's strategy, so the result is the same for any configured Strategy.
+// The value and combine functions may be called concurrently from multiple goroutines.
+func ReduceOrdered[T any](e *Executor, N int, identity T, value func(i int) T,
+	combine func(a, b T) T) T {
+
+	partials := make([]T, e.NumGoroutines())
+
+	e.forEachBlock(len(partials), N, func(block, start, stop, _ int) {
+		acc := identity
+		for i := start; i < stop; i++ {
+			acc = combine(acc, value(i))
+		}
+		partials[block] = acc
+	})
+
+	acc := identity
+	for _, partial := range partials {
+		acc = combine(acc, partial)
+	}
+	return acc
+}
@@ -0,0 +1,80 @@
+package parallel_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_ReduceOrdered_WithNonCommutativeCombine_CombinesInIndexOrder(t *testing.T) {
+	// arrange
+	N := 257
+	var sb strings.Builder
+	for i := 0; i < N; i++ {
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString(",")
+	}
+	expected := sb.String()
+	concat := func(a, b string) string {
+		return a + b
+	}
+
+	for _, numGR := range []int{1, 2, 3, 8} {
+		for _, strategy := range []parallel.StrategyType{
+			parallel.StrategyPreassignIndices, parallel.StrategyFetchNextIndex,
+		} {
+			e := parallel.NewExecutor().WithNumGoroutines(numGR).WithStrategy(strategy)
+
+			// act
+			actual := parallel.ReduceOrdered(e, N, "", func(i int) string {
+				return strconv.Itoa(i) + ","
+			}, concat)
+
+			// assert
+			if expected != actual {
+				t.Errorf("%d threads, strategy %d) expected %q, actual %q\n",
+					numGR, strategy, expected, actual)
+			}
+		}
+	}
+}
+
+func Test_ReduceOrdered_WithCustomStrategy_CombinesInIndexOrder(t *testing.T) {
+	// arrange
+	N := 15
+	expected := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
+	e := parallel.WithCustomStrategy(new(IncrementNumGRsStrategy)).WithNumGoroutines(4)
+
+	// act
+	actual := parallel.ReduceOrdered(e, N, nil, func(i int) []int {
+		return []int{i}
+	}, func(a, b []int) []int {
+		return append(append([]int(nil), a...), b...)
+	})
+
+	// assert
+	if fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Errorf("expected %v, actual %v\n", expected, actual)
+	}
+}
+
+func ExampleReduceOrdered() {
+	// compose affine transforms f(x) = a*x + b in index order
+	type affine struct{ a, b int }
+	transforms := []affine{{2, 1}, {1, 3}, {3, 0}, {1, -4}}
+	compose := func(f, g affine) affine {
+		// apply f, then g
+		return affine{g.a * f.a, g.a*f.b + g.b}
+	}
+
+	e := parallel.WithStrategy(parallel.StrategyFetchNextIndex).WithNumGoroutines(3)
+	h := parallel.ReduceOrdered(e, len(transforms), affine{1, 0}, func(i int) affine {
+		return transforms[i]
+	}, compose)
+
+	fmt.Println(h.a*5 + h.b)
+	// Output: 38
+}
@@ -0,0 +1,108 @@
+package parallel
+
+import (
+	"math"
+)
+
+// Summation identifies an algorithm for adding floating-point values.
+type Summation int
+
+const (
+	// SummationNaive adds values without compensating for rounding error.
+	SummationNaive = Summation(iota)
+
+	// SummationKahan adds values using Kahan compensated summation, which tracks the rounding
+	// error of each addition in a separate compensation term.
+	SummationKahan = Summation(iota)
+
+	// SummationNeumaier adds values using Neumaier's improvement to Kahan summation, which also
+	// compensates correctly when a term is larger in magnitude than the running sum.
+	SummationNeumaier = Summation(iota)
+)
+
+// reproducibleBlockSize is the number of consecutive work items summed serially by
+// ReproducibleSum before block results are combined.
+const reproducibleBlockSize = 1024
+
+// ReproducibleSum computes the sum of term(i) for i in [0, N) such that the result is bitwise
+// identical regardless of the number of goroutines or the strategy used by the executor.
+// Work indices are split into fixed-size blocks which are each summed serially in ascending index
+// order, and the block sums are combined in a fixed pairwise tree that depends only on N.
+// The summation argument selects whether compensated summation is used within blocks and when
+// combining block results.
+//
+// The result is generally not bitwise identical to a serial loop over all N terms, since the
+// order of additions differs, but it only changes when N or the terms change.
+// The term function may be called concurrently from multiple goroutines.
+func ReproducibleSum(e *Executor, N int, term func(i int) float64, summation Summation) float64 {
+	if N <= 0 {
+		return 0
+	}
+
+	numBlocks := (N + reproducibleBlockSize - 1) / reproducibleBlockSize
+	partials := make([]compensatedSum, numBlocks)
+
+	e.BlockExecutor().For(numBlocks, func(block, _ int) {
+		start := block * reproducibleBlockSize
+		stop := minInt(start+reproducibleBlockSize, N)
+
+		var acc compensatedSum
+		for i := start; i < stop; i++ {
+			acc.add(term(i), summation)
+		}
+		partials[block] = acc
+	})
+
+	e.mergeTree(numBlocks, func(dst, src int) {
+		partials[dst].merge(partials[src], summation)
+	})
+
+	return partials[0].value(summation)
+}
+
+// compensatedSum is a running sum with a compensation term holding accumulated rounding error,
+// such that the represented value is sum + compensation.
+type compensatedSum struct {
+	sum, compensation float64
+}
+
+func (s *compensatedSum) add(x float64, summation Summation) {
+	switch summation {
+	case SummationKahan:
+		y := x + s.compensation
+		t := s.sum + y
+		s.compensation = y - (t - s.sum)
+		s.sum = t
+	case SummationNeumaier:
+		t := s.sum + x
+		if math.Abs(s.sum) >= math.Abs(x) {
+			s.compensation += (s.sum - t) + x
+		} else {
+			s.compensation += (x - t) + s.sum
+		}
+		s.sum = t
+	default:
+		s.sum += x
+	}
+}
+
+func (s *compensatedSum) merge(other compensatedSum, summation Summation) {
+	if summation != SummationKahan && summation != SummationNeumaier {
+		s.sum += other.sum
+		return
+	}
+
+	// error-free transformation of the sum of the two leading terms
+	t := s.sum + other.sum
+	z := t - s.sum
+	err := (s.sum - (t - z)) + (other.sum - z)
+	s.compensation += other.compensation + err
+	s.sum = t
+}
+
+func (s *compensatedSum) value(summation Summation) float64 {
+	if summation != SummationKahan && summation != SummationNeumaier {
+		return s.sum
+	}
+	return s.sum + s.compensation
+}
@@ -0,0 +1,89 @@
+package parallel_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_ReproducibleSum_WithVaryingExecutors_ReturnsBitwiseIdenticalResults(t *testing.T) {
+	// arrange
+	N := 100003
+	r := rand.New(rand.NewSource(6))
+	x := make([]float64, N)
+	for i := range x {
+		x[i] = r.NormFloat64() * math.Pow(10, float64(r.Intn(20)-10))
+	}
+	term := func(i int) float64 {
+		return x[i]
+	}
+
+	for _, summation := range []parallel.Summation{
+		parallel.SummationNaive, parallel.SummationKahan, parallel.SummationNeumaier,
+	} {
+		expected := parallel.ReproducibleSum(parallel.WithNumGoroutines(1), N, term, summation)
+
+		for _, numGR := range []int{2, 3, 7, 16} {
+			for _, strategy := range []parallel.StrategyType{
+				parallel.StrategyPreassignIndices, parallel.StrategyFetchNextIndex,
+			} {
+				e := parallel.NewExecutor().WithNumGoroutines(numGR).WithStrategy(strategy)
+
+				// act
+				actual := parallel.ReproducibleSum(e, N, term, summation)
+
+				// assert
+				if math.Float64bits(expected) != math.Float64bits(actual) {
+					t.Errorf("summation %d, %d threads, strategy %d) expected %v, actual %v\n",
+						summation, numGR, strategy, expected, actual)
+				}
+			}
+		}
+	}
+}
+
+func Test_ReproducibleSum_WithNeumaierSummation_IsExactOnCancellingTerms(t *testing.T) {
+	// arrange
+	terms := []float64{1.0, 1e100, 1.0, -1e100}
+	N := 4000
+	expected := 2000.0
+
+	// act
+	actual := parallel.ReproducibleSum(parallel.WithNumGoroutines(3), N, func(i int) float64 {
+		return terms[i%len(terms)]
+	}, parallel.SummationNeumaier)
+
+	// assert
+	if expected != actual {
+		t.Errorf("expected %v, actual %v\n", expected, actual)
+	}
+}
+
+func Test_ReproducibleSum_WithNoWorkItems_ReturnsZero(t *testing.T) {
+	// act
+	actual := parallel.ReproducibleSum(parallel.NewExecutor(), 0, func(i int) float64 {
+		return 1.0
+	}, parallel.SummationKahan)
+
+	// assert
+	if actual != 0 {
+		t.Errorf("expected 0, actual %v\n", actual)
+	}
+}
+
+func ExampleReproducibleSum() {
+	N := 1000000
+	term := func(i int) float64 {
+		return 1.0 / float64(i+1)
+	}
+
+	// the result is identical for any number of goroutines
+	s2 := parallel.ReproducibleSum(parallel.WithNumGoroutines(2), N, term, parallel.SummationKahan)
+	s5 := parallel.ReproducibleSum(parallel.WithNumGoroutines(5), N, term, parallel.SummationKahan)
+
+	fmt.Println(s2 == s5)
+	// Output: true
+}
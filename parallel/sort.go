@@ -0,0 +1,145 @@
+package parallel
+
+import (
+	"cmp"
+	"slices"
+)
+
+// sortSerialCutoff is the partition size at or below which sorting is done serially.
+const sortSerialCutoff = 1 << 12
+
+// Sort sorts a slice of any ordered type in ascending order.
+// The slice is split into contiguous partitions that are sorted in parallel, and the sorted
+// partitions are then combined by rounds of parallel merges. Slices at or below a fixed size are
+// sorted serially. When sorting floating-point values, NaNs are ordered before other values.
+func Sort[T cmp.Ordered](e *Executor, x []T) {
+	mergeSort(e, x, cmp.Compare[T], func(x []T, _ func(a, b T) int) {
+		slices.Sort(x)
+	})
+}
+
+// SortFunc sorts the slice x in ascending order as determined by the cmp function, which must
+// return a negative number when a < b, a positive number when a > b and zero when a == b.
+// SortFunc is not guaranteed to be stable; for a stable sort, use SortStable.
+// The cmp function may be called concurrently from multiple goroutines.
+func SortFunc[T any](e *Executor, x []T, cmp func(a, b T) int) {
+	mergeSort(e, x, cmp, slices.SortFunc[[]T, T])
+}
+
+// SortStable sorts the slice x in ascending order as determined by the cmp function, keeping the
+// original order of equal elements. The result is identical to that of sort.Stable and
+// slices.SortStableFunc.
+// The cmp function may be called concurrently from multiple goroutines.
+func SortStable[T any](e *Executor, x []T, cmp func(a, b T) int) {
+	mergeSort(e, x, cmp, slices.SortStableFunc[[]T, T])
+}
+
+// mergeSort sorts contiguous partitions of x in parallel using sortPartition, then merges
+// neighbouring runs pairwise until a single sorted run remains. Merges are stable, so the
+// result is stable whenever sortPartition is.
+func mergeSort[T any](e *Executor, x []T, cmp func(a, b T) int,
+	sortPartition func(x []T, cmp func(a, b T) int)) {
+
+	N := len(x)
+	numPartitions := minInt(e.NumGoroutines(), (N+sortSerialCutoff-1)/sortSerialCutoff)
+	if numPartitions <= 1 {
+		sortPartition(x, cmp)
+		return
+	}
+
+	// sort each partition independently
+	bounds := make([]int, numPartitions+1)
+	e.forEachBlock(numPartitions, N, func(partition, start, stop, _ int) {
+		sortPartition(x[start:stop], cmp)
+		bounds[partition+1] = stop
+	})
+
+	// merge runs pairwise, alternating between x and a buffer
+	src, dst := x, make([]T, N)
+	for len(bounds) > 2 {
+		bounds = mergeRuns(e, src, dst, bounds, cmp)
+		src, dst = dst, src
+	}
+
+	if &src[0] != &x[0] {
+		e.forEachBlock(e.NumGoroutines(), N, func(_, start, stop, _ int) {
+			copy(x[start:stop], src[start:stop])
+		})
+	}
+}
+
+// mergeTask identifies one part of a merge of the sorted runs src[lo:mid] and src[mid:hi].
+type mergeTask struct {
+	lo, mid, hi int
+	part, parts int
+}
+
+// mergeRuns merges neighbouring pairs of sorted runs from src into dst and returns the bounds of
+// the merged runs. A trailing unpaired run is copied as is. Each merge is split into enough parts
+// that all goroutines have work, even on the final merge.
+func mergeRuns[T any](e *Executor, src, dst []T, bounds []int, cmp func(a, b T) int) []int {
+	numRuns := len(bounds) - 1
+	numMerges := numRuns / 2
+	partsPerMerge := maxInt((e.NumGoroutines()+numMerges-1)/numMerges, 1)
+
+	var tasks []mergeTask
+	mergedBounds := []int{0}
+	for r := 0; r+1 < numRuns; r += 2 {
+		lo, mid, hi := bounds[r], bounds[r+1], bounds[r+2]
+		for part := 0; part < partsPerMerge; part++ {
+			tasks = append(tasks, mergeTask{lo, mid, hi, part, partsPerMerge})
+		}
+		mergedBounds = append(mergedBounds, hi)
+	}
+	if numRuns%2 == 1 {
+		// copy unpaired run as a single-part merge with an empty right side
+		lo, hi := bounds[numRuns-1], bounds[numRuns]
+		tasks = append(tasks, mergeTask{lo, hi, hi, 0, 1})
+		mergedBounds = append(mergedBounds, hi)
+	}
+
+	e.BlockExecutor().For(len(tasks), func(t, _ int) {
+		task := tasks[t]
+		a, b := src[task.lo:task.mid], src[task.mid:task.hi]
+		k0 := (len(a) + len(b)) * task.part / task.parts
+		k1 := (len(a) + len(b)) * (task.part + 1) / task.parts
+		i0, i1 := mergeSplit(a, b, k0, cmp), mergeSplit(a, b, k1, cmp)
+		mergeInto(dst[task.lo+k0:task.lo+k1], a[i0:i1], b[k0-i0:k1-i1], cmp)
+	})
+
+	return mergedBounds
+}
+
+// mergeSplit returns the number of elements taken from a among the first k elements of the stable
+// merge of a and b, where elements of a precede equal elements of b.
+func mergeSplit[T any](a, b []T, k int, cmp func(a, b T) int) int {
+	lo, hi := maxInt(0, k-len(b)), minInt(k, len(a))
+	for lo < hi {
+		i := int(uint(lo+hi) >> 1)
+		j := k - i
+		if cmp(b[j-1], a[i]) >= 0 {
+			// a[i] must be taken before b[j-1]
+			lo = i + 1
+		} else {
+			hi = i
+		}
+	}
+	return lo
+}
+
+// mergeInto performs a serial stable merge of a and b into dst.
+func mergeInto[T any](dst, a, b []T, cmp func(a, b T) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
@@ -0,0 +1,137 @@
+package parallel_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+type sortRecord struct {
+	key, id int
+}
+
+func randomSortRecords(N, numKeys int, seed int64) []sortRecord {
+	r := rand.New(rand.NewSource(seed))
+	records := make([]sortRecord, N)
+	for i := range records {
+		records[i] = sortRecord{key: r.Intn(numKeys), id: i}
+	}
+	return records
+}
+
+func compareSortRecordKeys(a, b sortRecord) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+func Test_Sort_WithVaryingNumGoroutines_SortsAscending(t *testing.T) {
+	for _, N := range []int{0, 1, 100, 50000} {
+		for _, numGR := range []int{1, 2, 3, 8} {
+			// arrange
+			r := rand.New(rand.NewSource(int64(N)))
+			x := make([]float64, N)
+			for i := range x {
+				x[i] = r.NormFloat64()
+			}
+			expected := slices.Clone(x)
+			sort.Float64s(expected)
+
+			// act
+			parallel.Sort(parallel.NewExecutor().WithNumGoroutines(numGR), x)
+
+			// assert
+			if !slices.Equal(expected, x) {
+				t.Errorf("N = %d, %d threads) slice is not sorted\n", N, numGR)
+			}
+		}
+	}
+}
+
+func Test_SortFunc_WithFetchNextIndex_SortsAscending(t *testing.T) {
+	// arrange
+	x := randomSortRecords(30000, 500, 1)
+	e := parallel.NewExecutor().WithNumGoroutines(5).WithStrategy(parallel.StrategyFetchNextIndex)
+
+	// act
+	parallel.SortFunc(e, x, compareSortRecordKeys)
+
+	// assert
+	if !slices.IsSortedFunc(x, compareSortRecordKeys) {
+		t.Errorf("slice is not sorted\n")
+	}
+}
+
+func Test_SortStable_WithVaryingNumGoroutines_MatchesSortStable(t *testing.T) {
+	// arrange
+	input := randomSortRecords(60000, 100, 2)
+	expected := slices.Clone(input)
+	sort.SliceStable(expected, func(i, j int) bool {
+		return expected[i].key < expected[j].key
+	})
+
+	for _, numGR := range []int{1, 2, 3, 4, 7, 16} {
+		x := slices.Clone(input)
+
+		// act
+		parallel.SortStable(parallel.NewExecutor().WithNumGoroutines(numGR), x,
+			compareSortRecordKeys)
+
+		// assert
+		if !slices.Equal(expected, x) {
+			t.Errorf("%d threads) result does not match sort.SliceStable\n", numGR)
+		}
+	}
+}
+
+func ExampleSortStable() {
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"Ana", 31}, {"Ben", 25}, {"Cy", 31}, {"Di", 25}, {"Ed", 40}}
+
+	parallel.SortStable(parallel.WithNumGoroutines(2), people, func(a, b person) int {
+		return a.age - b.age
+	})
+
+	fmt.Println(people)
+	// Output: [{Ben 25} {Di 25} {Ana 31} {Cy 31} {Ed 40}]
+}
+
+func BenchmarkSortFloat64(b *testing.B) {
+	N := 1000000
+	input := make([]float64, N)
+	for i := range input {
+		input[i] = rand.Float64()
+	}
+	x := make([]float64, N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(x, input)
+		b.StartTimer()
+		parallel.Sort(parallel.NewExecutor(), x)
+	}
+}
+
+func BenchmarkSerialSortFloat64(b *testing.B) {
+	N := 1000000
+	input := make([]float64, N)
+	for i := range input {
+		input[i] = rand.Float64()
+	}
+	x := make([]float64, N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(x, input)
+		b.StartTimer()
+		slices.Sort(x)
+	}
+}
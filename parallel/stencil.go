@@ -0,0 +1,172 @@
+package parallel
+
+import (
+	"context"
+)
+
+// Grid is a two-dimensional grid of values stored in row-major order, such that the value at
+// column x and row y is Data[y*Width+x].
+type Grid struct {
+	Width, Height int
+	Data          []float64
+}
+
+// NewGrid returns a zero-valued grid with the given dimensions.
+func NewGrid(width, height int) *Grid {
+	return &Grid{
+		Width:  width,
+		Height: height,
+		Data:   make([]float64, width*height),
+	}
+}
+
+// At returns the value at column x and row y.
+func (g *Grid) At(x, y int) float64 {
+	return g.Data[y*g.Width+x]
+}
+
+// Set sets the value at column x and row y.
+func (g *Grid) Set(x, y int, v float64) {
+	g.Data[y*g.Width+x] = v
+}
+
+// Boundary identifies how a stencil reads values outside of the grid.
+type Boundary int
+
+const (
+	// BoundaryClamp reads the nearest value on the edge of the grid.
+	BoundaryClamp = Boundary(iota)
+
+	// BoundaryWrap reads values from the opposite side of the grid, as on a torus.
+	BoundaryWrap = Boundary(iota)
+
+	// BoundaryConstant reads a constant value, given by StencilOptions.BoundaryValue.
+	BoundaryConstant = Boundary(iota)
+)
+
+// StencilOptions configures a Stencil run.
+type StencilOptions struct {
+	// Boundary determines the values read outside of the grid.
+	Boundary Boundary
+
+	// BoundaryValue is the value read outside of the grid when Boundary is BoundaryConstant.
+	BoundaryValue float64
+
+	// MaxSteps is the maximum number of steps to run. If MaxSteps is not positive, steps run until
+	// Converged returns true or the context is ended.
+	MaxSteps int
+
+	// Converged, if not nil, is called after each step with the step number, starting from 0, and
+	// the grids before and after the step. Returning true stops the run. Converged is called from
+	// a single goroutine while all others wait, and the grids must not be retained, since their
+	// buffers are reused in the next step.
+	Converged func(step int, prev, next *Grid) bool
+}
+
+// Neighborhood provides a stencil function with access to the values around a grid cell.
+type Neighborhood struct {
+	grid          *Grid
+	x, y          int
+	boundary      Boundary
+	boundaryValue float64
+}
+
+// X returns the column of the cell being updated.
+func (n Neighborhood) X() int {
+	return n.x
+}
+
+// Y returns the row of the cell being updated.
+func (n Neighborhood) Y() int {
+	return n.y
+}
+
+// At returns the value at offset (dx, dy) from the cell being updated, as of the previous step.
+// Offsets outside of the grid are resolved according to the boundary policy.
+func (n Neighborhood) At(dx, dy int) float64 {
+	x, y := n.x+dx, n.y+dy
+	width, height := n.grid.Width, n.grid.Height
+
+	if x < 0 || x >= width || y < 0 || y >= height {
+		switch n.boundary {
+		case BoundaryWrap:
+			x = ((x % width) + width) % width
+			y = ((y % height) + height) % height
+		case BoundaryConstant:
+			return n.boundaryValue
+		default:
+			x = maxInt(0, minInt(x, width-1))
+			y = maxInt(0, minInt(y, height-1))
+		}
+	}
+
+	return n.grid.Data[y*width+x]
+}
+
+// Stencil repeatedly updates every cell of grid to the value returned by the stencil function,
+// which reads the cell's neighbourhood as of the previous step. Updates are double buffered, so
+// each step reads only values from the previous step, and the final state is left in grid.
+// The number of completed steps is returned.
+//
+// Rows of the grid are preassigned to goroutines in contiguous blocks, and the same goroutines
+// are kept for all steps, synchronising with a barrier between steps rather than being spawned
+// for each step. Steps run until opts.MaxSteps is reached, opts.Converged returns true, or ctx is
+// ended. The context is checked between steps; if it is ended, ctx.Err() is returned.
+// The stencil function may be called concurrently from multiple goroutines.
+func Stencil(ctx context.Context, e *Executor, grid *Grid, stencil func(n Neighborhood) float64,
+	opts StencilOptions) (int, error) {
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	prev, next := grid, NewGrid(grid.Width, grid.Height)
+	numSteps := 0
+	done := false
+	var err error
+
+	numGR := maxInt(e.loopGoroutines(grid.Height), 1)
+	e.region(numGR, func(grID int, b *barrier) {
+		startRow, stopRow := grIndexBlock(numGR, grID, grid.Height)
+
+		for !done {
+			// update this goroutine's rows from the previous state
+			src, dst := prev, next
+			for y := startRow; y < stopRow; y++ {
+				for x := 0; x < grid.Width; x++ {
+					dst.Data[y*grid.Width+x] = stencil(Neighborhood{
+						grid:          src,
+						x:             x,
+						y:             y,
+						boundary:      opts.Boundary,
+						boundaryValue: opts.BoundaryValue,
+					})
+				}
+			}
+
+			b.await(func() {
+				step := numSteps
+				numSteps++
+				prev, next = next, prev
+
+				switch {
+				case opts.Converged != nil && opts.Converged(step, next, prev):
+					done = true
+				case opts.MaxSteps > 0 && numSteps >= opts.MaxSteps:
+					done = true
+				case ctx.Err() != nil:
+					done = true
+					err = ctx.Err()
+				}
+			})
+		}
+
+		// leave the final state in the caller's grid
+		if prev != grid {
+			start, stop := startRow*grid.Width, stopRow*grid.Width
+			copy(grid.Data[start:stop], prev.Data[start:stop])
+		}
+	})
+
+	return numSteps, err
+}
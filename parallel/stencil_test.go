@@ -0,0 +1,151 @@
+package parallel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func fivePointAverage(n parallel.Neighborhood) float64 {
+	return (n.At(0, 0) + n.At(-1, 0) + n.At(1, 0) + n.At(0, -1) + n.At(0, 1)) / 5
+}
+
+// serialFivePointAverage computes steps of fivePointAverage serially with constant boundaries.
+func serialFivePointAverage(g *parallel.Grid, steps int, boundaryValue float64) *parallel.Grid {
+	at := func(g *parallel.Grid, x, y int) float64 {
+		if x < 0 || x >= g.Width || y < 0 || y >= g.Height {
+			return boundaryValue
+		}
+		return g.At(x, y)
+	}
+
+	cur := &parallel.Grid{Width: g.Width, Height: g.Height, Data: append([]float64(nil), g.Data...)}
+	for s := 0; s < steps; s++ {
+		next := parallel.NewGrid(g.Width, g.Height)
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				next.Set(x, y, (at(cur, x, y)+at(cur, x-1, y)+at(cur, x+1, y)+
+					at(cur, x, y-1)+at(cur, x, y+1))/5)
+			}
+		}
+		cur = next
+	}
+	return cur
+}
+
+func Test_Stencil_WithConstantBoundary_MatchesSerialComputation(t *testing.T) {
+	// arrange
+	initial := parallel.NewGrid(13, 9)
+	initial.Set(6, 4, 100)
+	steps := 7
+	expected := serialFivePointAverage(initial, steps, 1.5)
+
+	for _, numGR := range []int{1, 2, 3, 4, 16} {
+		grid := &parallel.Grid{Width: 13, Height: 9, Data: append([]float64(nil), initial.Data...)}
+		opts := parallel.StencilOptions{
+			Boundary:      parallel.BoundaryConstant,
+			BoundaryValue: 1.5,
+			MaxSteps:      steps,
+		}
+
+		// act
+		numSteps, err := parallel.Stencil(context.Background(), parallel.WithNumGoroutines(numGR),
+			grid, fivePointAverage, opts)
+
+		// assert
+		if err != nil || numSteps != steps {
+			t.Errorf("%d threads) expected %d steps without error, actual %d steps, error %v\n",
+				numGR, steps, numSteps, err)
+		}
+		assertFloat64SlicesEqual(t, expected.Data, grid.Data, fmt.Sprintf("%d threads) ", numGR))
+	}
+}
+
+func Test_Stencil_WithWrapBoundary_ConservesTotal(t *testing.T) {
+	// arrange
+	grid := parallel.NewGrid(8, 8)
+	grid.Set(0, 0, 64)
+	opts := parallel.StencilOptions{Boundary: parallel.BoundaryWrap, MaxSteps: 25}
+
+	// act
+	_, _ = parallel.Stencil(context.Background(), parallel.WithNumGoroutines(3), grid,
+		fivePointAverage, opts)
+
+	// assert
+	total := 0.0
+	for _, v := range grid.Data {
+		total += v
+	}
+	if math.Abs(total-64) > 1e-9 {
+		t.Errorf("expected total 64, actual %v\n", total)
+	}
+}
+
+func Test_Stencil_WithConvergedCallback_StopsWhenConverged(t *testing.T) {
+	// arrange
+	grid := parallel.NewGrid(6, 5)
+	grid.Set(2, 2, 30)
+	opts := parallel.StencilOptions{
+		Boundary: parallel.BoundaryClamp,
+		Converged: func(_ int, prev, next *parallel.Grid) bool {
+			maxDiff := 0.0
+			for i := range prev.Data {
+				maxDiff = math.Max(maxDiff, math.Abs(next.Data[i]-prev.Data[i]))
+			}
+			return maxDiff < 1e-6
+		},
+	}
+
+	// act
+	numSteps, err := parallel.Stencil(context.Background(), parallel.WithNumGoroutines(2),
+		grid, fivePointAverage, opts)
+
+	// assert
+	if err != nil || numSteps == 0 {
+		t.Errorf("expected convergence without error, actual %d steps, error %v\n", numSteps, err)
+	}
+	for _, v := range grid.Data {
+		if math.Abs(v-1) > 1e-3 {
+			t.Errorf("expected all values near 1, actual %v\n", grid.Data)
+			break
+		}
+	}
+}
+
+func Test_Stencil_WithTimeout_ReturnsContextError(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	grid := parallel.NewGrid(4, 4)
+	slowStencil := func(n parallel.Neighborhood) float64 {
+		time.Sleep(100 * time.Microsecond)
+		return n.At(0, 0)
+	}
+
+	// act
+	_, err := parallel.Stencil(ctx, parallel.WithNumGoroutines(2), grid, slowStencil,
+		parallel.StencilOptions{})
+
+	// assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, actual %v\n", err)
+	}
+}
+
+func ExampleStencil() {
+	// diffuse heat along a rod held at zero at both ends
+	rod := &parallel.Grid{Width: 5, Height: 1, Data: []float64{0, 0, 90, 0, 0}}
+
+	steps, _ := parallel.Stencil(context.Background(), parallel.WithNumGoroutines(2), rod,
+		func(n parallel.Neighborhood) float64 {
+			return (n.At(-1, 0) + n.At(0, 0) + n.At(1, 0)) / 3
+		}, parallel.StencilOptions{Boundary: parallel.BoundaryConstant, MaxSteps: 2})
+
+	fmt.Println(steps, rod.Data)
+	// Output: 2 [10 20 30 20 10]
+}
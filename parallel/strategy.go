@@ -17,6 +17,12 @@ const (
 	// This strategy generally works best for API requests.
 	StrategyFetchNextIndex = StrategyType(iota)
 
+	// StrategyAuto refers to a strategy that starts with preassigned blocks of work indices, as
+	// in StrategyPreassignIndices, and switches the remaining work to dynamic distribution if the
+	// loop iterations turn out to be inconsistent in time. This strategy works best when it is not
+	// known in advance how consistent the loop iterations are. See AutoStrategy for details.
+	StrategyAuto = StrategyType(iota)
+
 	// StrategyUseDefaults may be specified on WithStrategy() calls to set the executor to use the
 	// default strategies for both For() and ForWithContext().
 	StrategyUseDefaults = StrategyType(-1)
@@ -43,3 +49,11 @@ type Strategy interface {
 type IndexGenerator interface {
 	Next() int
 }
+
+// perLoopStrategy is implemented by strategies whose index generators share state for the duration
+// of a single loop, such as a shared counter. Executors request a fresh instance for each loop so
+// that state from a previous loop is not carried over.
+type perLoopStrategy interface {
+	Strategy
+	newLoop() Strategy
+}
@@ -0,0 +1,106 @@
+package parallel
+
+import (
+	"context"
+)
+
+// Supersteps executes a bulk-synchronous iterative computation. Each superstep s executes
+// step(s, i, grID) for every work index i in [0, N), after which afterStep(s) is called. Steps
+// continue until afterStep returns true, and the number of completed steps is returned.
+// This correlates to a loop of the form:
+//
+//		for s := 0; ; s++ {
+//			e.For(N, func(i, grID int) {
+//				step(s, i, grID)
+//			})
+//			if afterStep(s) {
+//				break
+//			}
+//		}
+//
+// Unlike repeated calls to For(), the same goroutines are kept for all steps and synchronise with
+// a barrier between steps, which removes the cost of spawning goroutines for each step.
+// The afterStep function is called from a single goroutine while all others wait, so it may
+// safely read results of the step and update state used by the next step.
+// Work indices are distributed within each step according to the executor's strategy, which
+// defaults to contiguous index blocks. The step function may be called concurrently from
+// multiple goroutines.
+func Supersteps(e *Executor, N int, step func(s, i, grID int),
+	afterStep func(s int) (done bool)) int {
+
+	numSteps, _ := SuperstepsWithContext(context.Background(), e, N, step, afterStep)
+	return numSteps
+}
+
+// SuperstepsWithContext is the same as Supersteps(), but includes a context argument which is
+// checked between steps. If ctx is ended, no further steps are started, and the number of
+// completed steps is returned along with ctx.Err().
+func SuperstepsWithContext(ctx context.Context, e *Executor, N int, step func(s, i, grID int),
+	afterStep func(s int) (done bool)) (int, error) {
+
+	return SuperstepsReduce(ctx, e, N, struct{}{},
+		func(s, i, grID int) struct{} {
+			step(s, i, grID)
+			return struct{}{}
+		},
+		func(struct{}, struct{}) struct{} {
+			return struct{}{}
+		},
+		func(s int, _ struct{}) bool {
+			return afterStep(s)
+		})
+}
+
+// SuperstepsReduce is the same as SuperstepsWithContext(), but with a reduction built into each
+// step. The values returned by step are combined into a single result for the step, which is
+// passed to afterStep; for example, the result may be the residual used to test for convergence.
+// Each goroutine combines its values into a local partial result starting from identity, and the
+// partial results are combined in goroutine ID order when the step completes. Since the indices
+// assigned to each goroutine depend on the executor, combine must be associative and commutative.
+func SuperstepsReduce[T any](ctx context.Context, e *Executor, N int, identity T,
+	step func(s, i, grID int) T, combine func(a, b T) T,
+	afterStep func(s int, result T) (done bool)) (int, error) {
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	numGR := maxInt(e.loopGoroutines(N), 1)
+	partials := make([]T, numGR)
+	strategy := e.loopStrategy(newContiguousBlocksStrategy)
+	numSteps := 0
+	done := false
+	var err error
+
+	e.region(numGR, func(grID int, b *barrier) {
+		for s := 0; !done; s++ {
+			partial := identity
+			indexGenerator := strategy.IndexGenerator(numGR, grID, N)
+			for i := indexGenerator.Next(); i < N; i = indexGenerator.Next() {
+				partial = combine(partial, step(s, i, grID))
+			}
+			partials[grID] = partial
+
+			b.await(func() {
+				result := identity
+				for _, partial := range partials {
+					result = combine(result, partial)
+				}
+				numSteps++
+
+				switch {
+				case afterStep(s, result):
+					done = true
+				case ctx.Err() != nil:
+					done = true
+					err = ctx.Err()
+				default:
+					// fresh strategy instance for the next step
+					strategy = e.loopStrategy(newContiguousBlocksStrategy)
+				}
+			})
+		}
+	})
+
+	return numSteps, err
+}
@@ -0,0 +1,148 @@
+package parallel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_Supersteps_WithVaryingExecutors_ExecutesEveryIndexEachStep(t *testing.T) {
+	// arrange
+	N := 101
+	numSteps := 5
+
+	for _, strategy := range []parallel.StrategyType{
+		parallel.StrategyPreassignIndices, parallel.StrategyFetchNextIndex,
+	} {
+		for _, numGR := range []int{1, 2, 3, 8} {
+			counts := make([]int, N)
+			e := parallel.NewExecutor().WithNumGoroutines(numGR).WithStrategy(strategy)
+
+			// act
+			actualSteps := parallel.Supersteps(e, N, func(s, i, _ int) {
+				if counts[i] != s {
+					t.Errorf("index %d executed out of step\n", i)
+				}
+				counts[i]++
+			}, func(s int) bool {
+				return s == numSteps-1
+			})
+
+			// assert
+			if actualSteps != numSteps {
+				t.Errorf("%d threads, strategy %d) expected %d steps, actual %d\n",
+					numGR, strategy, numSteps, actualSteps)
+			}
+			for i, count := range counts {
+				if count != numSteps {
+					t.Errorf("%d threads, strategy %d) index %d executed %d times\n",
+						numGR, strategy, i, count)
+					break
+				}
+			}
+		}
+	}
+}
+
+func Test_SuperstepsReduce_WithJacobiIteration_ConvergesToSolution(t *testing.T) {
+	// arrange
+	// solve the diagonally dominant system 4x[i] - x[i-1] - x[i+1] = 2 with zero boundaries
+	N := 50
+	x, xNext := make([]float64, N), make([]float64, N)
+	at := func(i int) float64 {
+		if i < 0 || i >= N {
+			return 0
+		}
+		return x[i]
+	}
+
+	// act
+	numSteps, err := parallel.SuperstepsReduce(context.Background(), parallel.WithNumGoroutines(3),
+		N, 0.0, func(_, i, _ int) float64 {
+			xNext[i] = (2 + at(i-1) + at(i+1)) / 4
+			return math.Abs(xNext[i] - x[i])
+		}, math.Max, func(_ int, maxChange float64) bool {
+			x, xNext = xNext, x
+			return maxChange < 1e-12
+		})
+
+	// assert
+	if err != nil || numSteps < 2 {
+		t.Errorf("expected convergence without error, actual %d steps, error %v\n", numSteps, err)
+	}
+	for i := 0; i < N; i++ {
+		residual := 4*x[i] - at(i-1) - at(i+1) - 2
+		if math.Abs(residual) > 1e-9 {
+			t.Errorf("index %d) residual %v is too large\n", i, residual)
+		}
+	}
+}
+
+func Test_SuperstepsWithContext_WithCancellation_StopsBetweenSteps(t *testing.T) {
+	// arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// act
+	numSteps, err := parallel.SuperstepsWithContext(ctx, parallel.WithNumGoroutines(2), 10,
+		func(s, _, _ int) {
+			if s == 2 {
+				cancel()
+			}
+		}, func(int) bool {
+			return false
+		})
+
+	// assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, actual %v\n", err)
+	}
+	if numSteps != 3 {
+		t.Errorf("expected 3 steps, actual %d\n", numSteps)
+	}
+}
+
+func ExampleSuperstepsReduce() {
+	// repeatedly halve values until their total drops below 1
+	values := []float64{64, 32, 16, 8}
+
+	numSteps, _ := parallel.SuperstepsReduce(context.Background(), parallel.WithNumGoroutines(2),
+		len(values), 0.0, func(_, i, _ int) float64 {
+			values[i] /= 2
+			return values[i]
+		}, func(a, b float64) float64 {
+			return a + b
+		}, func(_ int, total float64) bool {
+			return total < 1
+		})
+
+	fmt.Println(numSteps, values)
+	// Output: 7 [0.5 0.25 0.125 0.0625]
+}
+
+func Test_Supersteps_WithFewerIndicesThanGoroutines_CapsGoroutines(t *testing.T) {
+	// arrange
+	N := 3
+	numGR := 8
+	e := parallel.NewExecutor(parallel.NumGoroutines(numGR))
+	seen := make([]int32, numGR)
+
+	// act
+	parallel.Supersteps(e, N, func(_, _, grID int) {
+		atomic.StoreInt32(&seen[grID], 1)
+	}, func(s int) bool {
+		return s == 2
+	})
+
+	// assert
+	for grID := N; grID < numGR; grID++ {
+		if seen[grID] != 0 {
+			t.Errorf("expected grIDs below %d, received %d\n", N, grID)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package parallel
+
+import (
+	"slices"
+)
+
+// TopK returns the k elements of in that come first when ordered by less, sorted in that order.
+// Elements that are equal according to less are ranked by their index in in, so the result does
+// not depend on the number of goroutines or the strategy used by the executor.
+// If k exceeds len(in), all elements are returned in sorted order.
+// Each goroutine keeps a bounded heap of its best k elements, and the heaps are merged once the
+// loop completes. The less function may be called concurrently from multiple goroutines.
+func TopK[T any](e *Executor, in []T, k int, less func(a, b T) bool) []T {
+	k = minInt(k, len(in))
+	if k <= 0 {
+		return []T{}
+	}
+
+	// ranksBefore reports whether in[i] ranks strictly before in[j], breaking ties by index
+	ranksBefore := func(i, j int) bool {
+		if less(in[i], in[j]) {
+			return true
+		}
+		if less(in[j], in[i]) {
+			return false
+		}
+		return i < j
+	}
+
+	heaps := make([]topKHeap, e.NumGoroutines())
+	for grID := range heaps {
+		heaps[grID] = topKHeap{k: k, ranksBefore: ranksBefore}
+	}
+
+	e.For(len(in), func(i, grID int) {
+		heaps[grID].offer(i)
+	})
+
+	// merge candidates from all goroutines and keep the best k
+	var candidates []int
+	for _, h := range heaps {
+		candidates = append(candidates, h.indices...)
+	}
+	slices.SortFunc(candidates, func(i, j int) int {
+		if ranksBefore(i, j) {
+			return -1
+		}
+		if ranksBefore(j, i) {
+			return 1
+		}
+		return 0
+	})
+
+	out := make([]T, k)
+	for r := range out {
+		out[r] = in[candidates[r]]
+	}
+	return out
+}
+
+// topKHeap is a bounded heap of indices with the lowest ranked index at the root.
+type topKHeap struct {
+	k           int
+	ranksBefore func(i, j int) bool
+	indices     []int
+}
+
+// offer adds index i to the heap if it is among the best k indices offered so far.
+func (h *topKHeap) offer(i int) {
+	if len(h.indices) < h.k {
+		h.indices = append(h.indices, i)
+		h.up(len(h.indices) - 1)
+		return
+	}
+
+	if h.ranksBefore(i, h.indices[0]) {
+		h.indices[0] = i
+		h.down(0)
+	}
+}
+
+func (h *topKHeap) up(child int) {
+	for child > 0 {
+		parent := (child - 1) / 2
+		if !h.ranksBefore(h.indices[parent], h.indices[child]) {
+			return
+		}
+		h.indices[parent], h.indices[child] = h.indices[child], h.indices[parent]
+		child = parent
+	}
+}
+
+func (h *topKHeap) down(parent int) {
+	n := len(h.indices)
+	for {
+		worst := parent
+		if left := 2*parent + 1; left < n && h.ranksBefore(h.indices[worst], h.indices[left]) {
+			worst = left
+		}
+		if right := 2*parent + 2; right < n && h.ranksBefore(h.indices[worst], h.indices[right]) {
+			worst = right
+		}
+		if worst == parent {
+			return
+		}
+		h.indices[parent], h.indices[worst] = h.indices[worst], h.indices[parent]
+		parent = worst
+	}
+}
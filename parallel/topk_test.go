@@ -0,0 +1,97 @@
+package parallel_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_TopK_WithVaryingNumGoroutines_ReturnsSameResult(t *testing.T) {
+	// arrange
+	input := randomSortRecords(5000, 50, 3)
+	k := 120
+	expected := append([]sortRecord(nil), input...)
+	sort.SliceStable(expected, func(i, j int) bool {
+		return expected[i].key > expected[j].key
+	})
+	expected = expected[:k]
+	greater := func(a, b sortRecord) bool {
+		return a.key > b.key
+	}
+
+	for _, numGR := range []int{1, 2, 3, 8} {
+		for _, strategy := range []parallel.StrategyType{
+			parallel.StrategyPreassignIndices, parallel.StrategyFetchNextIndex,
+		} {
+			e := parallel.NewExecutor().WithNumGoroutines(numGR).WithStrategy(strategy)
+
+			// act
+			actual := parallel.TopK(e, input, k, greater)
+
+			// assert
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("%d threads, strategy %d) result does not match stable sort\n",
+					numGR, strategy)
+			}
+		}
+	}
+}
+
+func Test_TopK_WithKLargerThanInput_ReturnsAllSorted(t *testing.T) {
+	// arrange
+	input := []int{5, 2, 8, 1}
+	expected := []int{1, 2, 5, 8}
+
+	// act
+	actual := parallel.TopK(parallel.WithNumGoroutines(3), input, 10, func(a, b int) bool {
+		return a < b
+	})
+
+	// assert
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, actual %v\n", expected, actual)
+	}
+}
+
+func Test_TopK_WithNonPositiveK_ReturnsEmptySlice(t *testing.T) {
+	// act
+	actual := parallel.TopK(parallel.NewExecutor(), []int{1, 2, 3}, 0, func(a, b int) bool {
+		return a < b
+	})
+
+	// assert
+	if len(actual) != 0 {
+		t.Errorf("expected empty result, actual %v\n", actual)
+	}
+}
+
+func ExampleTopK() {
+	scores := []float64{0.2, 0.9, 0.4, 0.95, 0.1, 0.9, 0.7}
+
+	// select the 3 highest scores
+	best := parallel.TopK(parallel.WithNumGoroutines(2), scores, 3, func(a, b float64) bool {
+		return a > b
+	})
+
+	fmt.Println(best)
+	// Output: [0.95 0.9 0.9]
+}
+
+func BenchmarkTopK(b *testing.B) {
+	N := 1000000
+	input := make([]float64, N)
+	for i := range input {
+		input[i] = rand.Float64()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parallel.TopK(parallel.NewExecutor(), input, 100, func(a, b float64) bool {
+			return a > b
+		})
+	}
+}
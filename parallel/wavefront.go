@@ -0,0 +1,85 @@
+package parallel
+
+import (
+	"sync/atomic"
+)
+
+// defaultWavefrontTileSize is the tile height and width used by Wavefront when not specified.
+const defaultWavefrontTileSize = 64
+
+// WavefrontOptions configures a Wavefront execution.
+type WavefrontOptions struct {
+	// TileRows and TileCols are the dimensions of the tiles that are scheduled on goroutines.
+	// Larger tiles reduce scheduling overhead, while smaller tiles expose more parallelism.
+	// Values less than 1 default to 64.
+	TileRows, TileCols int
+}
+
+// Wavefront executes cell(i, j, grID) for every cell of a rows x cols grid such that each cell is
+// executed only after its north (i-1, j), west (i, j-1) and north-west (i-1, j-1) neighbours have
+// completed, as needed by dynamic programming recurrences such as edit distance.
+//
+// The grid is divided into tiles which are processed along anti-diagonals. Rather than waiting
+// for each anti-diagonal to complete, a tile is started as soon as the tiles to its north and
+// west have completed. Within a tile, cells are executed in row-major order by a single
+// goroutine. The goroutine ID passed to cell ranges from 0 to NumGoroutines - 1.
+// The cell function may be called concurrently from multiple goroutines.
+func Wavefront(e *Executor, rows, cols int, cell func(i, j, grID int), opts WavefrontOptions) {
+	if rows <= 0 || cols <= 0 {
+		return
+	}
+
+	tileRows, tileCols := opts.TileRows, opts.TileCols
+	if tileRows < 1 {
+		tileRows = defaultWavefrontTileSize
+	}
+	if tileCols < 1 {
+		tileCols = defaultWavefrontTileSize
+	}
+	numTileRows := (rows + tileRows - 1) / tileRows
+	numTileCols := (cols + tileCols - 1) / tileCols
+	numTiles := numTileRows * numTileCols
+
+	// count unfinished dependencies of each tile; the north-west dependency is implied
+	pending := make([]int32, numTiles)
+	for r := 0; r < numTileRows; r++ {
+		for c := 0; c < numTileCols; c++ {
+			if r > 0 {
+				pending[r*numTileCols+c]++
+			}
+			if c > 0 {
+				pending[r*numTileCols+c]++
+			}
+		}
+	}
+
+	ready := make(chan int, numTiles)
+	ready <- 0
+	var numCompleted int64
+
+	e.region(maxInt(e.loopGoroutines(numTiles), 1), func(grID int, _ *barrier) {
+		for tile := range ready {
+			r, c := tile/numTileCols, tile%numTileCols
+
+			iStop := minInt((r+1)*tileRows, rows)
+			jStop := minInt((c+1)*tileCols, cols)
+			for i := r * tileRows; i < iStop; i++ {
+				for j := c * tileCols; j < jStop; j++ {
+					cell(i, j, grID)
+				}
+			}
+
+			// release dependent tiles to the south and east
+			if r+1 < numTileRows && atomic.AddInt32(&pending[tile+numTileCols], -1) == 0 {
+				ready <- tile + numTileCols
+			}
+			if c+1 < numTileCols && atomic.AddInt32(&pending[tile+1], -1) == 0 {
+				ready <- tile + 1
+			}
+
+			if atomic.AddInt64(&numCompleted, 1) == int64(numTiles) {
+				close(ready)
+			}
+		}
+	})
+}
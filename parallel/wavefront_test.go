@@ -0,0 +1,112 @@
+package parallel_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+// editDistanceTable fills the edit distance dynamic programming table for a and b using
+// Wavefront, and returns the table.
+func editDistanceTable(e *parallel.Executor, a, b string, opts parallel.WavefrontOptions) [][]int {
+	rows, cols := len(a)+1, len(b)+1
+	table := make([][]int, rows)
+	for i := range table {
+		table[i] = make([]int, cols)
+	}
+
+	parallel.Wavefront(e, rows, cols, func(i, j, _ int) {
+		switch {
+		case i == 0:
+			table[i][j] = j
+		case j == 0:
+			table[i][j] = i
+		default:
+			substitution := table[i-1][j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			table[i][j] = minOf(substitution, table[i-1][j]+1, table[i][j-1]+1)
+		}
+	}, opts)
+
+	return table
+}
+
+func minOf(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func Test_Wavefront_WithVaryingTileSizes_ComputesEditDistance(t *testing.T) {
+	// arrange
+	a := "the quick brown fox jumps over the lazy dog, again and again"
+	b := "a quick brown dog jumped over the lazy fox and again"
+	expected := editDistanceTable(parallel.WithNumGoroutines(1), a, b,
+		parallel.WavefrontOptions{TileRows: 1000, TileCols: 1000})
+
+	for _, numGR := range []int{1, 2, 4, 7} {
+		for _, tileSize := range []int{1, 3, 8, 0} {
+			opts := parallel.WavefrontOptions{TileRows: tileSize, TileCols: tileSize + 2}
+
+			// act
+			actual := editDistanceTable(parallel.WithNumGoroutines(numGR), a, b, opts)
+
+			// assert
+			if fmt.Sprint(expected) != fmt.Sprint(actual) {
+				t.Errorf("%d threads, tile size %d) table does not match serial computation\n",
+					numGR, tileSize)
+			}
+		}
+	}
+}
+
+func Test_Wavefront_WithEmptyGrid_DoesNotExecuteCells(t *testing.T) {
+	// arrange
+	executed := false
+
+	// act
+	parallel.Wavefront(parallel.NewExecutor(), 0, 5, func(_, _, _ int) {
+		executed = true
+	}, parallel.WavefrontOptions{})
+
+	// assert
+	if executed {
+		t.Errorf("expected no cells to be executed\n")
+	}
+}
+
+func ExampleWavefront() {
+	a, b := "kitten", "sitting"
+	rows, cols := len(a)+1, len(b)+1
+	dist := make([][]int, rows)
+	for i := range dist {
+		dist[i] = make([]int, cols)
+	}
+
+	// each cell depends on its north, west and north-west neighbours
+	opts := parallel.WavefrontOptions{TileRows: 2, TileCols: 3}
+	parallel.Wavefront(parallel.WithNumGoroutines(3), rows, cols, func(i, j, _ int) {
+		switch {
+		case i == 0:
+			dist[i][j] = j
+		case j == 0:
+			dist[i][j] = i
+		default:
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			dist[i][j] = minOf(dist[i-1][j-1]+cost, dist[i-1][j]+1, dist[i][j-1]+1)
+		}
+	}, opts)
+
+	fmt.Println(dist[rows-1][cols-1])
+	// Output: 3
+}
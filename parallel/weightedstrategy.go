@@ -0,0 +1,101 @@
+package parallel
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+type weightedBlocksStrategy struct {
+	cost func(i int) float64
+
+	mu      sync.Mutex
+	prefixN int
+	prefix  []float64 // prefix[i] is the total cost of indices [0, i)
+}
+
+// NewWeightedStrategy returns a strategy that preassigns each goroutine a contiguous block of work
+// indices, like StrategyPreassignIndices, but where blocks have roughly equal total cost rather
+// than equal numbers of indices. The cost of index i is given by costs[i], which must have at
+// least N elements for loops of N iterations. Costs only need to be relative to each other; for
+// example, they may be the sizes of files or the number of nonzero elements in matrix rows.
+// Negative, NaN and infinite costs are treated as zero. Costs are read when a loop starts, and the
+// resulting prefix sums are reused by subsequent loops with the same number of iterations, so a
+// new strategy must be created if costs change between such loops.
+// The returned strategy is used with WithCustomStrategy().
+func NewWeightedStrategy(costs []float64) Strategy {
+	return NewWeightedStrategyFunc(func(i int) float64 {
+		return costs[i]
+	})
+}
+
+// NewWeightedStrategyFunc is the same as NewWeightedStrategy(), but with the cost of each index
+// given by a function. The cost function is called once per index when a loop starts, and the
+// resulting prefix sums are reused by subsequent loops with the same number of iterations.
+func NewWeightedStrategyFunc(cost func(i int) float64) Strategy {
+	return &weightedBlocksStrategy{
+		cost: cost,
+	}
+}
+
+func (s *weightedBlocksStrategy) IndexGenerator(numGR, grID, N int) IndexGenerator {
+	startIndex, stopIndex := weightedIndexBlock(s.prefixSums(N), numGR, grID)
+
+	return &contiguousIndexGenerator{
+		startIndex: startIndex,
+		stopIndex:  stopIndex,
+		doneIndex:  N,
+		nextIndex:  startIndex,
+	}
+}
+
+// prefixSums returns the prefix sums of costs for N work indices, computing them if they have
+// not already been computed for N.
+func (s *weightedBlocksStrategy) prefixSums(N int) []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.prefix == nil || s.prefixN != N {
+		s.prefix = costPrefixSums(s.cost, N)
+		s.prefixN = N
+	}
+	return s.prefix
+}
+
+// costPrefixSums computes the cumulative cost of work indices, treating invalid costs as zero.
+func costPrefixSums(cost func(i int) float64, N int) []float64 {
+	prefix := make([]float64, maxInt(N, 0)+1)
+	for i := 0; i < N; i++ {
+		prefix[i+1] = prefix[i] + validCost(cost(i))
+	}
+	return prefix
+}
+
+// validCost returns c if it is a valid cost estimate, or zero if c is negative, NaN or infinite.
+func validCost(c float64) float64 {
+	if !(c > 0) || math.IsInf(c, 1) {
+		return 0
+	}
+	return c
+}
+
+// weightedIndexBlock computes the contiguous index range for a goroutine with given ID such that
+// each range has roughly equal total cost according to the cost prefix sums.
+func weightedIndexBlock(prefix []float64, numGR, grID int) (int, int) {
+	N := len(prefix) - 1
+	total := prefix[N]
+	if total == 0 {
+		// no cost information, so fall back to equal numbers of indices
+		return grIndexBlock(numGR, grID, N)
+	}
+
+	bound := func(g int) int {
+		if g >= numGR {
+			return N
+		}
+		target := total * float64(g) / float64(numGR)
+		return sort.SearchFloat64s(prefix, target)
+	}
+
+	return bound(grID), bound(grID + 1)
+}
@@ -0,0 +1,94 @@
+package parallel_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/dgravesa/go-parallel/parallel"
+)
+
+func Test_WeightedStrategy_WithSkewedCosts_AssignsEqualCostContiguousBlocks(t *testing.T) {
+	// arrange
+	N := 1000
+	costs := make([]float64, N)
+	totalCost := 0.0
+	for i := range costs {
+		costs[i] = float64(i * i)
+		totalCost += costs[i]
+	}
+
+	for _, numGR := range []int{1, 2, 3, 8} {
+		e := parallel.WithCustomStrategy(parallel.NewWeightedStrategy(costs)).
+			WithNumGoroutines(numGR)
+		grIDs := make([]int, N)
+		visits := make([]int, N)
+
+		// act
+		e.For(N, func(i, grID int) {
+			grIDs[i] = grID
+			visits[i]++
+		})
+
+		// assert
+		grCosts := make([]float64, numGR)
+		for i := 0; i < N; i++ {
+			if visits[i] != 1 {
+				t.Fatalf("%d threads) index %d visited %d times\n", numGR, i, visits[i])
+			}
+			if i > 0 && grIDs[i] < grIDs[i-1] {
+				t.Fatalf("%d threads) blocks are not contiguous at index %d\n", numGR, i)
+			}
+			grCosts[grIDs[i]] += costs[i]
+		}
+		for grID, grCost := range grCosts {
+			// each block may be unbalanced by at most one item's cost
+			if math.Abs(grCost-totalCost/float64(numGR)) > costs[N-1] {
+				t.Errorf("%d threads) goroutine %d has cost %v, expected approximately %v\n",
+					numGR, grID, grCost, totalCost/float64(numGR))
+			}
+		}
+	}
+}
+
+func Test_WeightedStrategyFunc_WithZeroOrInvalidCosts_VisitsEachIndexOnce(t *testing.T) {
+	// arrange
+	N := 50
+	strategy := parallel.NewWeightedStrategyFunc(func(i int) float64 {
+		if i%2 == 0 {
+			return math.NaN()
+		}
+		return -1
+	})
+
+	for _, N := range []int{N, N / 2} {
+		visits := make([]int, N)
+
+		// act
+		parallel.WithCustomStrategy(strategy).WithNumGoroutines(4).For(N, func(i, _ int) {
+			visits[i]++
+		})
+
+		// assert
+		for i, v := range visits {
+			if v != 1 {
+				t.Errorf("N = %d) index %d visited %d times\n", N, i, v)
+			}
+		}
+	}
+}
+
+func ExampleNewWeightedStrategy() {
+	// the last two items cost as much as all of the others combined
+	costs := []float64{1, 1, 1, 1, 1, 1, 1, 1, 4, 4}
+	N := len(costs)
+	workerGrIDs := make([]int, N)
+
+	strategy := parallel.NewWeightedStrategy(costs)
+	parallel.WithCustomStrategy(strategy).WithNumGoroutines(2).For(N, func(i, grID int) {
+		workerGrIDs[i] = grID
+	})
+
+	fmt.Println(workerGrIDs)
+	// Output: [0 0 0 0 0 0 0 0 1 1]
+}